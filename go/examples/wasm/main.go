@@ -0,0 +1,55 @@
+//go:build js && wasm
+
+// Command wasm demonstrates running the gs engine in a browser via
+// GOOS=js GOARCH=wasm. Build it with:
+//
+//	GOOS=js GOARCH=wasm go build -o main.wasm ./examples/wasm
+//
+// and load it alongside Go's wasm_exec.js support file. It exposes a single
+// global JavaScript function, gsExecute(code), that runs code against a
+// small counter DSL and returns a JSON envelope (see gs.Engine.ExecuteJSON)
+// for live editing/validation of LLM-constrained DSL in the browser.
+package main
+
+import (
+	"syscall/js"
+
+	"grammar-school/go/gs"
+)
+
+// counterDSL is a minimal DSL for the example: increment/reset a single
+// counter and report its value.
+type counterDSL struct {
+	count float64
+}
+
+func (c *counterDSL) Increment(args gs.Args) (gs.Value, error) {
+	by := 1.0
+	if n, ok := args.GetNumber("by"); ok {
+		by = n
+	}
+	c.count += by
+	return gs.NewNumberValue(c.count), nil
+}
+
+func (c *counterDSL) Reset(args gs.Args) (gs.Value, error) {
+	c.count = 0
+	return gs.NewNumberValue(c.count), nil
+}
+
+func main() {
+	dsl := &counterDSL{}
+	engine, err := gs.NewEngine("", dsl, &gs.DefaultParser{})
+	if err != nil {
+		panic(err)
+	}
+
+	js.Global().Set("gsExecute", js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) == 0 {
+			return engine.ExecuteJSON("")
+		}
+		return engine.ExecuteJSON(args[0].String())
+	}))
+
+	select {} // keep the program (and its registered callback) alive
+}
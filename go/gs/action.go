@@ -0,0 +1,10 @@
+package gs
+
+// Action is an internal representation of a single unit of work produced by
+// the interpreter. It exists to support the two-layer architecture
+// (parse -> Action -> Runtime) for advanced users; the unified Engine.Execute
+// path calls method handlers directly and does not require Actions.
+type Action struct {
+	Kind    string
+	Payload map[string]interface{}
+}
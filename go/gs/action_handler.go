@@ -0,0 +1,88 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ActionHandler is like VerbHandler, except instead of performing work
+// itself it returns the Actions that represent the work to be done. The
+// Engine dispatches each Action in order to the Runtime configured via
+// SetRuntime (see runtime.go), stopping at the first one that fails, rather
+// than calling the handler's effects directly. This lets a single DSL mix
+// methods that execute immediately (VerbHandler, ResultHandler) with ones
+// that only describe what should happen, e.g. so a Runtime can log, batch,
+// or replay them before anything actually runs.
+type ActionHandler func(args Args) ([]Action, error)
+
+var actionHandlerType = reflect.TypeOf((func(Args) ([]Action, error))(nil))
+
+// discoverActionMethods registers every method of dsl matching
+// func(Args) ([]Action, error) as an ActionHandler, the same way
+// discoverMethods registers func(Args) error methods as VerbHandlers,
+// including the same collision detection for methods that register the same
+// snake_case name.
+func (e *Engine) discoverActionMethods(dsl interface{}) error {
+	v := reflect.ValueOf(dsl)
+	t := v.Type()
+	e.actionVerbsMu.Lock()
+	defer e.actionVerbsMu.Unlock()
+	claimedBy := make(map[string]string, t.NumMethod())
+	var collisions []string
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		methodValue := v.Method(i)
+		if methodValue.Type() != actionHandlerType {
+			continue
+		}
+		key := e.methodKey(toSnakeCase(m.Name))
+		if first, ok := claimedBy[key]; ok {
+			collisions = append(collisions, fmt.Sprintf("%q (from %s and %s)", key, first, m.Name))
+			continue
+		}
+		claimedBy[key] = m.Name
+		handler := methodValue.Interface().(func(Args) ([]Action, error))
+		e.actionVerbs[key] = ActionHandler(handler)
+	}
+	collisions = append(collisions, e.ambiguousEmbeddedCollisions(dsl, actionHandlerType)...)
+	return collisionError(collisions)
+}
+
+// RegisterActionMethod registers handler under name as an ActionHandler,
+// overwriting any ActionHandler already registered under that name. A
+// VerbHandler or ResultHandler registered under the same name is left in
+// place and still takes precedence at dispatch.
+func (e *Engine) RegisterActionMethod(name string, handler ActionHandler) {
+	e.actionVerbsMu.Lock()
+	defer e.actionVerbsMu.Unlock()
+	e.actionVerbs[e.methodKey(name)] = handler
+}
+
+func (e *Engine) actionVerb(name string) (ActionHandler, bool) {
+	e.actionVerbsMu.RLock()
+	defer e.actionVerbsMu.RUnlock()
+	handler, ok := e.actionVerbs[e.methodKey(name)]
+	return handler, ok
+}
+
+// dispatchActions calls actionHandler with args, then runs every Action it
+// returns against the Engine's configured Runtime, in order, stopping at
+// the first one that fails. It returns an error if no Runtime has been
+// configured via SetRuntime, since there would otherwise be nowhere for the
+// Actions to go.
+func (e *Engine) dispatchActions(ctx context.Context, method string, actionHandler ActionHandler, args Args) error {
+	actions, err := actionHandler(args)
+	if err != nil {
+		return err
+	}
+	if e.runtime == nil {
+		return fmt.Errorf("gs: %s: no Runtime configured for action-returning methods (see Engine.SetRuntime)", method)
+	}
+	for _, action := range actions {
+		if err := e.runtime.ExecuteAction(ctx, action); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+type aliasDSL struct {
+	muted bool
+}
+
+func (d *aliasDSL) Mute(args Args) error {
+	d.muted = true
+	return nil
+}
+
+func TestAliasDispatchesToTargetMethod(t *testing.T) {
+	dsl := &aliasDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Alias("silence", "mute"); err != nil {
+		t.Fatalf("Alias: %v", err)
+	}
+	if err := e.Execute(context.Background(), "silence()"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !dsl.muted {
+		t.Error("silence() did not dispatch to Mute")
+	}
+}
+
+func TestAliasErrorsOnUnknownTarget(t *testing.T) {
+	dsl := &aliasDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Alias("silence", "does_not_exist"); err == nil {
+		t.Error("Alias succeeded aliasing an unregistered target, want an error")
+	}
+}
@@ -0,0 +1,124 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+)
+
+// AnthropicCFGProvider implements CFGProvider for Claude's tool-use API.
+// Claude does not have a dedicated CFG/grammar tool type, so BuildTool
+// packages the grammar as a custom tool definition whose input schema
+// documents the DSL's syntax for the model.
+type AnthropicCFGProvider struct{}
+
+// BuildTool builds a Claude tool-use payload carrying the cleaned grammar.
+func (p *AnthropicCFGProvider) BuildTool(toolName, description, grammar, syntax string) (map[string]any, error) {
+	if err := ValidateGrammar(grammar); err != nil {
+		return nil, err
+	}
+	if syntax == "" {
+		syntax = SyntaxLark
+	}
+	return map[string]any{
+		"name":        toolName,
+		"description": description,
+		"input_schema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"dsl_code": map[string]any{
+					"type":        "string",
+					"description": fmt.Sprintf("DSL code conforming to the following %s grammar:\n%s", syntax, CleanGrammarForCFG(grammar)),
+				},
+			},
+			"required": []string{"dsl_code"},
+		},
+	}, nil
+}
+
+// GetTextFormat returns an empty text format: Claude's API has no separate
+// text-format switch for grammar-constrained tool use.
+func (p *AnthropicCFGProvider) GetTextFormat() map[string]any {
+	return map[string]any{}
+}
+
+// ValidateGrammarForSyntax checks grammar's general structure. Claude has no
+// native grammar-constrained tool type, so BuildTool only embeds the
+// grammar as descriptive text — any syntax label is accepted.
+func (p *AnthropicCFGProvider) ValidateGrammarForSyntax(grammar, syntax string) error {
+	return ValidateGrammar(grammar)
+}
+
+// anthropicMessenger is the minimal surface Generate needs from a Claude
+// client. Callers pass an adapter around their SDK of choice (e.g.
+// github.com/anthropics/anthropic-sdk-go) as the client argument.
+type anthropicMessenger interface {
+	CreateMessage(ctx context.Context, params map[string]any) (map[string]any, error)
+}
+
+// Generate calls Claude's Messages API with prompt sent as the sole user
+// message, plus model, tools and kwargs merged into the request parameters.
+// client must implement anthropicMessenger.
+func (p *AnthropicCFGProvider) Generate(ctx context.Context, prompt, model string, tools []map[string]any, textFormat map[string]any, client interface{}, kwargs map[string]any) (interface{}, error) {
+	messenger, ok := client.(anthropicMessenger)
+	if !ok {
+		return nil, fmt.Errorf("gs: client must implement gs.anthropicMessenger to use AnthropicCFGProvider.Generate")
+	}
+	params := map[string]any{
+		"model": model,
+		"messages": []map[string]any{
+			{"role": "user", "content": prompt},
+		},
+		"tools": tools,
+	}
+	for k, v := range textFormat {
+		params[k] = v
+	}
+	for k, v := range kwargs {
+		params[k] = v
+	}
+	return messenger.CreateMessage(ctx, params)
+}
+
+// ExtractDSLCode pulls the generated DSL code out of a Claude Messages API
+// result. response may be a plain string, or a map[string]any shaped like
+// the Messages API payload: its "content" blocks are scanned for a
+// "tool_use" block's "dsl_code" input field first (BuildTool's input_schema
+// names that field), falling back to the first "text" block if no tool use
+// is present. The result is passed through StripMarkdownFences, since
+// Claude may still wrap a text-only answer in a code fence.
+func (p *AnthropicCFGProvider) ExtractDSLCode(response interface{}) (string, error) {
+	switch r := response.(type) {
+	case string:
+		return StripMarkdownFences(r), nil
+	case map[string]any:
+		content, ok := r["content"].([]any)
+		if !ok {
+			return "", fmt.Errorf("gs: could not find DSL code in Anthropic response")
+		}
+		var textFallback string
+		for _, c := range content {
+			block, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			switch block["type"] {
+			case "tool_use":
+				if input, ok := block["input"].(map[string]any); ok {
+					if code, ok := input["dsl_code"].(string); ok {
+						return StripMarkdownFences(code), nil
+					}
+				}
+			case "text":
+				if text, ok := block["text"].(string); ok && textFallback == "" {
+					textFallback = text
+				}
+			}
+		}
+		if textFallback != "" {
+			return StripMarkdownFences(textFallback), nil
+		}
+		return "", fmt.Errorf("gs: could not find DSL code in Anthropic response")
+	default:
+		return "", fmt.Errorf("gs: unsupported response type %T", response)
+	}
+}
@@ -0,0 +1,108 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+// sampleAnthropicResponse mimics a Claude Messages API response carrying a
+// tool_use block whose input has the dsl_code field BuildTool's
+// input_schema names.
+func sampleAnthropicResponse(dslCode string) map[string]any {
+	return map[string]any{
+		"id":   "msg_01",
+		"type": "message",
+		"role": "assistant",
+		"content": []any{
+			map[string]any{
+				"type": "text",
+				"text": "Here's the DSL code:",
+			},
+			map[string]any{
+				"type": "tool_use",
+				"id":   "toolu_01",
+				"name": "emit_dsl",
+				"input": map[string]any{
+					"dsl_code": dslCode,
+				},
+			},
+		},
+	}
+}
+
+func TestAnthropicExtractDSLCodeFromToolUse(t *testing.T) {
+	p := &AnthropicCFGProvider{}
+	got, err := p.ExtractDSLCode(sampleAnthropicResponse(`play(track="intro")`))
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestAnthropicExtractDSLCodeFallsBackToText(t *testing.T) {
+	p := &AnthropicCFGProvider{}
+	response := map[string]any{
+		"content": []any{
+			map[string]any{"type": "text", "text": "```\nplay(track=\"intro\")\n```"},
+		},
+	}
+	got, err := p.ExtractDSLCode(response)
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestAnthropicExtractDSLCodeFromPlainString(t *testing.T) {
+	p := &AnthropicCFGProvider{}
+	got, err := p.ExtractDSLCode("play(track=\"intro\")")
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestAnthropicExtractDSLCodeErrorsWithoutContent(t *testing.T) {
+	p := &AnthropicCFGProvider{}
+	if _, err := p.ExtractDSLCode(map[string]any{}); err == nil {
+		t.Error("ExtractDSLCode succeeded on a response with no content, want an error")
+	}
+}
+
+type fakeAnthropicMessenger struct {
+	gotParams map[string]any
+	response  map[string]any
+}
+
+func (f *fakeAnthropicMessenger) CreateMessage(ctx context.Context, params map[string]any) (map[string]any, error) {
+	f.gotParams = params
+	return f.response, nil
+}
+
+func TestAnthropicGenerateUsesClient(t *testing.T) {
+	client := &fakeAnthropicMessenger{response: sampleAnthropicResponse(`play(track="intro")`)}
+	p := &AnthropicCFGProvider{}
+	result, err := p.Generate(context.Background(), "play the intro", "claude-opus", nil, nil, client, nil)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if client.gotParams["model"] != "claude-opus" {
+		t.Errorf("model = %v, want %q", client.gotParams["model"], "claude-opus")
+	}
+	if result == nil {
+		t.Error("Generate returned a nil result")
+	}
+}
+
+func TestAnthropicGenerateRejectsIncompatibleClient(t *testing.T) {
+	p := &AnthropicCFGProvider{}
+	if _, err := p.Generate(context.Background(), "prompt", "claude-opus", nil, nil, "not a client", nil); err == nil {
+		t.Error("Generate succeeded with an incompatible client, want an error")
+	}
+}
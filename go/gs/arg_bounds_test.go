@@ -0,0 +1,90 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type boundsDSL struct {
+	ran bool
+}
+
+func (d *boundsDSL) SetGain(args Args) error {
+	d.ran = true
+	return nil
+}
+
+func TestSetArgBoundsAllowsInRangeValue(t *testing.T) {
+	dsl := &boundsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgBounds("set_gain", "gain", 0, 11)
+
+	if err := e.Execute(context.Background(), "set_gain(gain=5)"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !dsl.ran {
+		t.Error("SetGain was not invoked for an in-range value")
+	}
+}
+
+func TestSetArgBoundsRejectsBelowMin(t *testing.T) {
+	dsl := &boundsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgBounds("set_gain", "gain", 0, 11)
+
+	err = e.Execute(context.Background(), "set_gain(gain=-1)")
+	var boundsErr *BoundsError
+	if !errors.As(err, &boundsErr) {
+		t.Fatalf("Execute error = %v, want *BoundsError", err)
+	}
+	if boundsErr.Arg != "gain" || boundsErr.Min != 0 || boundsErr.Max != 11 {
+		t.Errorf("BoundsError = %+v, want Arg=gain Min=0 Max=11", boundsErr)
+	}
+	if dsl.ran {
+		t.Error("SetGain was invoked for a below-min value")
+	}
+}
+
+func TestSetArgBoundsRejectsAboveMax(t *testing.T) {
+	dsl := &boundsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgBounds("set_gain", "gain", 0, 11)
+
+	err = e.Execute(context.Background(), "set_gain(gain=9999)")
+	var boundsErr *BoundsError
+	if !errors.As(err, &boundsErr) {
+		t.Fatalf("Execute error = %v, want *BoundsError", err)
+	}
+	if boundsErr.Value != 9999 {
+		t.Errorf("BoundsError.Value = %g, want 9999", boundsErr.Value)
+	}
+	if dsl.ran {
+		t.Error("SetGain was invoked for an above-max value")
+	}
+}
+
+func TestSetArgBoundsAppliesAfterCoercion(t *testing.T) {
+	dsl := &boundsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetCoerceArgs(true)
+	e.SetArgBounds("set_gain", "gain", 0, 11)
+
+	err = e.Execute(context.Background(), `set_gain(gain="9999")`)
+	var boundsErr *BoundsError
+	if !errors.As(err, &boundsErr) {
+		t.Fatalf("Execute error = %v, want *BoundsError from a coerced string argument", err)
+	}
+}
@@ -0,0 +1,58 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+type defaultsDSL struct {
+	gotColor  string
+	gotLength float64
+}
+
+func (d *defaultsDSL) Paint(args Args) error {
+	d.gotColor, _ = args.GetString("color")
+	d.gotLength, _ = args.GetNumber("length")
+	return nil
+}
+
+func (defaultsDSL) DefaultArgs(method string) Args {
+	if method != "paint" {
+		return nil
+	}
+	return Args{"color": NewStringValue("blue"), "length": NewNumberValue(4)}
+}
+
+func TestArgDefaulterFillsMissingArgs(t *testing.T) {
+	dsl := &defaultsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Execute(context.Background(), "paint()"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if dsl.gotColor != "blue" {
+		t.Errorf("color = %q, want default %q", dsl.gotColor, "blue")
+	}
+	if dsl.gotLength != 4 {
+		t.Errorf("length = %v, want default 4", dsl.gotLength)
+	}
+}
+
+func TestArgDefaulterExplicitValueOverridesDefault(t *testing.T) {
+	dsl := &defaultsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Execute(context.Background(), `paint(color="red")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if dsl.gotColor != "red" {
+		t.Errorf("color = %q, want explicit %q to win over the default", dsl.gotColor, "red")
+	}
+	if dsl.gotLength != 4 {
+		t.Errorf("length = %v, want default 4 since it wasn't overridden", dsl.gotLength)
+	}
+}
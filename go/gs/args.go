@@ -0,0 +1,68 @@
+package gs
+
+import (
+	"sort"
+	"strconv"
+)
+
+// positionalIndex reports whether key is a reserved positional argument key
+// produced by PositionalArgName, and if so, which index it encodes.
+func positionalIndex(key string) (int, bool) {
+	if len(key) < 2 || key[0] != '_' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(key[1:])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// SortedKeys returns a's keys in a stable order: positional arguments first,
+// in numeric index order, followed by named arguments in alphabetical
+// order. This makes logging, re-serialization, and golden tests over Args
+// deterministic despite Args being a map. Internal reserved keys (like the
+// coercion flag SetCoerceArgs sets) are never included.
+func (a Args) SortedKeys() []string {
+	var positional []int
+	var named []string
+	for k := range a {
+		if k == coerceArgKey || k == currentArgKey {
+			continue
+		}
+		if i, ok := positionalIndex(k); ok {
+			positional = append(positional, i)
+			continue
+		}
+		named = append(named, k)
+	}
+	sort.Ints(positional)
+	sort.Strings(named)
+	keys := make([]string, 0, len(positional)+len(named))
+	for _, i := range positional {
+		keys = append(keys, PositionalArgName(i))
+	}
+	return append(keys, named...)
+}
+
+// Each calls fn once for every argument in a, in the order SortedKeys
+// defines, rather than Go's randomized map iteration order.
+func (a Args) Each(fn func(name string, v Value)) {
+	for _, k := range a.SortedKeys() {
+		fn(k, a[k])
+	}
+}
+
+// ToPayload converts every argument in a to a native Go value via
+// Value.Interface, keyed the same way a itself is (positional arguments
+// keep their PositionalArgName key as-is). This is useful for building an
+// Action's Payload from a call's args without each method handler manually
+// copying fields over one at a time. The internal coercion-flag key that
+// SetCoerceArgs sets is never included.
+func (a Args) ToPayload() map[string]interface{} {
+	payload := make(map[string]interface{}, len(a))
+	a.Each(func(name string, v Value) {
+		payload[name] = v.Interface()
+	})
+	return payload
+}
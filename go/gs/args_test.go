@@ -0,0 +1,46 @@
+package gs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArgsSortedKeysPositionalThenAlphabetical(t *testing.T) {
+	args := Args{
+		"zebra":              NewStringValue("z"),
+		"apple":              NewStringValue("a"),
+		PositionalArgName(1): NewNumberValue(1),
+		PositionalArgName(0): NewNumberValue(0),
+		coerceArgKey:         NewBoolValue(true),
+	}
+	got := args.SortedKeys()
+	want := []string{PositionalArgName(0), PositionalArgName(1), "apple", "zebra"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+}
+
+func TestArgsEachVisitsInSortedOrder(t *testing.T) {
+	args := Args{
+		"b": NewStringValue("b"),
+		"a": NewStringValue("a"),
+	}
+	var visited []string
+	args.Each(func(name string, v Value) {
+		visited = append(visited, name)
+	})
+	if want := []string{"a", "b"}; !reflect.DeepEqual(visited, want) {
+		t.Errorf("Each visited %v, want %v", visited, want)
+	}
+}
+
+func TestArgsSortedKeysSkipsInternalKeys(t *testing.T) {
+	args := Args{
+		"name":       NewStringValue("x"),
+		coerceArgKey: NewBoolValue(true),
+	}
+	got := args.SortedKeys()
+	if want := []string{"name"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v (internal keys excluded)", got, want)
+	}
+}
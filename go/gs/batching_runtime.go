@@ -0,0 +1,58 @@
+package gs
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchingRuntime wraps another Runtime, buffering Actions instead of
+// forwarding them immediately and flushing them as a batch once the buffer
+// reaches batchSize or Flush is called explicitly. It is useful when the
+// wrapped Runtime's ExecuteAction has fixed per-call overhead (e.g. a
+// network round trip) that amortizes better over a batch.
+type BatchingRuntime struct {
+	next      Runtime
+	batchSize int
+	mu        sync.Mutex
+	buf       []Action
+}
+
+// NewBatchingRuntime returns a BatchingRuntime that forwards to next,
+// flushing automatically once batchSize Actions have accumulated. A
+// batchSize <= 0 disables automatic flushing; callers must call Flush.
+func NewBatchingRuntime(next Runtime, batchSize int) *BatchingRuntime {
+	return &BatchingRuntime{next: next, batchSize: batchSize}
+}
+
+// ExecuteAction implements Runtime by buffering a, flushing automatically
+// once the buffer reaches batchSize.
+func (r *BatchingRuntime) ExecuteAction(ctx context.Context, a Action) error {
+	r.mu.Lock()
+	r.buf = append(r.buf, a)
+	shouldFlush := r.batchSize > 0 && len(r.buf) >= r.batchSize
+	r.mu.Unlock()
+	if shouldFlush {
+		return r.Flush(ctx)
+	}
+	return nil
+}
+
+// Flush forwards every buffered Action to the wrapped Runtime, in order,
+// stopping at the first error. Actions that already succeeded are dropped;
+// the failing action and anything buffered after it remain queued for the
+// next Flush.
+func (r *BatchingRuntime) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	buf := r.buf
+	r.buf = nil
+	r.mu.Unlock()
+	for i, a := range buf {
+		if err := r.next.ExecuteAction(ctx, a); err != nil {
+			r.mu.Lock()
+			r.buf = append(append([]Action{}, buf[i:]...), r.buf...)
+			r.mu.Unlock()
+			return err
+		}
+	}
+	return nil
+}
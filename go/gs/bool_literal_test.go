@@ -0,0 +1,45 @@
+package gs
+
+import "testing"
+
+func TestParseBoolLiteralSpellings(t *testing.T) {
+	cases := []struct {
+		spelling string
+		want     bool
+	}{
+		{"true", true},
+		{"yes", true},
+		{"on", true},
+		{"false", false},
+		{"no", false},
+		{"off", false},
+	}
+	p := &DefaultParser{}
+	for _, c := range cases {
+		t.Run(c.spelling, func(t *testing.T) {
+			chain, err := p.Parse(`flag(enabled=` + c.spelling + `)`)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", c.spelling, err)
+			}
+			v := chain.Calls[0].Args[0].Value
+			if v.Kind != ValueBool {
+				t.Fatalf("Kind = %v, want ValueBool", v.Kind)
+			}
+			if v.Bool != c.want {
+				t.Errorf("Bool = %v, want %v", v.Bool, c.want)
+			}
+		})
+	}
+}
+
+func TestParseBoolLiteralAsBareIdentifierArg(t *testing.T) {
+	p := &DefaultParser{}
+	chain, err := p.Parse(`flag(true)`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	v := chain.Calls[0].Args[0].Value
+	if v.Kind != ValueBool || !v.Bool {
+		t.Errorf("Value = %+v, want a bare ValueBool true", v)
+	}
+}
@@ -0,0 +1,200 @@
+package gs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Syntax and text format constants for OpenAI-style CFG tools.
+const (
+	SyntaxLark     = "lark"  // Default syntax for CFG grammars
+	SyntaxRegex    = "regex" // Regex syntax for CFG grammars
+	TextFormatType = "text"  // Text format type for OpenAI CFG requests
+)
+
+// CFGConfig configures an OpenAI CFG tool.
+type CFGConfig struct {
+	ToolName    string // Name of the tool that will receive the DSL output
+	Description string // Description of what the tool does
+	Grammar     string // Lark or regex grammar definition
+	Syntax      string // "lark" or "regex" (default: "lark")
+}
+
+// ValidateGrammar performs a lightweight structural check on a grammar
+// string before it is handed to a CFG tool: it must be non-empty once
+// cleaned, and must define a "start" rule, since that is the entry point
+// every parser backend expects.
+func ValidateGrammar(grammar string) error {
+	cleaned := CleanGrammarForCFG(grammar)
+	if cleaned == "" {
+		return fmt.Errorf("gs: grammar is empty")
+	}
+	hasStart := false
+	for _, line := range strings.Split(cleaned, "\n") {
+		name, _, found := strings.Cut(strings.TrimSpace(line), ":")
+		if found && strings.TrimSpace(strings.TrimPrefix(name, "?")) == "start" {
+			hasStart = true
+			break
+		}
+	}
+	if !hasStart {
+		return fmt.Errorf("gs: grammar has no \"start\" rule")
+	}
+	return nil
+}
+
+// BuildOpenAICFGTool builds an OpenAI CFG tool payload from a CFGConfig. The
+// grammar is validated with ValidateGrammar and cleaned with
+// CleanGrammarForCFG. Syntax defaults to SyntaxLark when unset.
+func BuildOpenAICFGTool(config CFGConfig) (map[string]any, error) {
+	if err := ValidateGrammar(config.Grammar); err != nil {
+		return nil, err
+	}
+	syntax := config.Syntax
+	if syntax == "" {
+		syntax = SyntaxLark
+	}
+	return map[string]any{
+		"type":        "custom",
+		"name":        config.ToolName,
+		"description": config.Description,
+		"format": map[string]any{
+			"type":       "grammar",
+			"syntax":     syntax,
+			"definition": CleanGrammarForCFG(config.Grammar),
+		},
+		"json_schema": BuildJSONSchemaForTool(config.Description),
+	}, nil
+}
+
+// BuildJSONSchemaForTool returns a minimal JSON schema describing the
+// "dsl_code" string a CFG tool ultimately produces. Providers that don't
+// support CFG grammars directly can fall back to this schema, and those
+// that do support CFG can still surface it for documentation/introspection.
+func BuildJSONSchemaForTool(description string) map[string]any {
+	return map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"dsl_code": map[string]any{
+				"type":        "string",
+				"description": description,
+			},
+		},
+		"required": []string{"dsl_code"},
+	}
+}
+
+// ValidateAgainstGrammar checks that code parses as a syntactically valid
+// DSL program, on top of the generic structural checks ValidateGrammar runs
+// on grammar itself. This guards against CFG providers (like Anthropic's
+// and Google's, which only embed the grammar as descriptive text rather
+// than natively enforcing it) where the model may still produce code the
+// grammar doesn't actually allow.
+func ValidateAgainstGrammar(code, grammar string) error {
+	if err := ValidateGrammar(grammar); err != nil {
+		return err
+	}
+	if _, err := (&DefaultParser{}).Parse(code); err != nil {
+		return fmt.Errorf("gs: code does not conform to grammar: %w", err)
+	}
+	return nil
+}
+
+// DefaultCallChainRegex is a best-effort regex matching the default
+// call-chain DSL syntax (call(name=value, ...).call(...)), for CFG
+// providers that only support regex-syntax grammars.
+const DefaultCallChainRegex = `^\s*[A-Za-z_][A-Za-z0-9_]*\s*\([^()]*\)(\s*\.\s*[A-Za-z_][A-Za-z0-9_]*\s*\([^()]*\))*\s*$`
+
+// GrammarToRegex attempts a fallback conversion of a Lark grammar into an
+// equivalent regex for CFG providers that only support SyntaxRegex. This is
+// necessarily best-effort: Lark's recursive, context-free grammars cannot
+// generally be represented as a regular expression. Grammars that look like
+// Grammar School's default call-chain grammar (they define a call_chain
+// rule) get DefaultCallChainRegex; anything else is reported as
+// unsupported so callers can supply their own regex.
+func GrammarToRegex(grammar string) (string, error) {
+	cleaned := CleanGrammarForCFG(grammar)
+	if cleaned == "" {
+		return "", fmt.Errorf("gs: grammar is empty")
+	}
+	if strings.Contains(cleaned, "call_chain") {
+		return DefaultCallChainRegex, nil
+	}
+	return "", fmt.Errorf("gs: no regex fallback is known for this grammar; supply one via CFGConfig directly")
+}
+
+// GetOpenAITextFormatForCFG returns the text format configuration that must
+// be used when making OpenAI requests with CFG tools, since the output is
+// DSL code rather than JSON.
+func GetOpenAITextFormatForCFG() map[string]any {
+	return map[string]any{
+		"format": map[string]any{"type": TextFormatType},
+	}
+}
+
+// CleanGrammarForCFG cleans a grammar string for use with CFG systems (e.g.
+// GPT-5). It strips Lark-specific directives (lines starting with "%" such
+// as %import and %ignore), "//" and "#" comments, and blank lines so only
+// the grammar rules reach the model. It is equivalent to
+// CleanGrammarForCFGWith(grammar, nil).
+func CleanGrammarForCFG(grammar string) string {
+	return CleanGrammarForCFGWith(grammar, nil)
+}
+
+// CleanGrammarForCFGWith behaves like CleanGrammarForCFG, except a directive
+// line (one starting with "%") whose keyword (its first whitespace-
+// separated token, e.g. "%ignore") appears in keep is preserved instead of
+// being stripped. This lets callers keep directives a specific CFG backend
+// actually understands (e.g. "%ignore") while still dropping ones it
+// doesn't (e.g. "%import").
+func CleanGrammarForCFGWith(grammar string, keep []string) string {
+	keepSet := make(map[string]bool, len(keep))
+	for _, k := range keep {
+		keepSet[k] = true
+	}
+	lines := strings.Split(grammar, "\n")
+	cleaned := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(stripLineComment(line))
+		if trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "%") && !keepSet[strings.Fields(trimmed)[0]] {
+			continue
+		}
+		cleaned = append(cleaned, trimmed)
+	}
+	return strings.Join(cleaned, "\n")
+}
+
+// stripLineComment returns line with any trailing "//" or "#" comment
+// removed. It tracks whether it is inside a single- or double-quoted
+// terminal so a "//" or "#" quoted as part of the grammar itself (e.g.
+// `A: "//" "text"  // real comment`) is left alone and only the real
+// trailing comment is dropped.
+func stripLineComment(line string) string {
+	var quote rune
+	escaped := false
+	for i, r := range line {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case r == '\\':
+				escaped = true
+			case r == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return line[:i]
+		case r == '/' && i+1 < len(line) && line[i+1] == '/':
+			return line[:i]
+		}
+	}
+	return line
+}
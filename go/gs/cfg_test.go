@@ -0,0 +1,59 @@
+package gs
+
+import "testing"
+
+func TestCleanGrammarForCFG(t *testing.T) {
+	cases := []struct {
+		name    string
+		grammar string
+		want    string
+	}{
+		{
+			name:    "strips slash-slash comment",
+			grammar: "start: call_chain // the entry point",
+			want:    "start: call_chain",
+		},
+		{
+			name:    "strips hash comment",
+			grammar: "start: call_chain # the entry point",
+			want:    "start: call_chain",
+		},
+		{
+			name:    "strips whole-line hash comment",
+			grammar: "# a rule comment\nstart: call_chain",
+			want:    "start: call_chain",
+		},
+		{
+			name:    "preserves slash-slash inside a quoted terminal",
+			grammar: `A: "//" "text"  // real comment`,
+			want:    `A: "//" "text"`,
+		},
+		{
+			name:    "preserves hash inside a quoted terminal",
+			grammar: `A: "#" "text"  # real comment`,
+			want:    `A: "#" "text"`,
+		},
+		{
+			name:    "strips import directive and blank lines",
+			grammar: "start: call_chain\n\n%import common.WS\n%ignore WS",
+			want:    "start: call_chain",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CleanGrammarForCFG(tc.grammar)
+			if got != tc.want {
+				t.Errorf("CleanGrammarForCFG(%q) = %q, want %q", tc.grammar, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCleanGrammarForCFGWithKeepsAllowedDirectives(t *testing.T) {
+	grammar := "start: call_chain\n%import common.WS\n%ignore WS"
+	got := CleanGrammarForCFGWith(grammar, []string{"%ignore"})
+	want := "start: call_chain\n%ignore WS"
+	if got != want {
+		t.Errorf("CleanGrammarForCFGWith(...) = %q, want %q", got, want)
+	}
+}
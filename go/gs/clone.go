@@ -0,0 +1,72 @@
+package gs
+
+// Clone returns a new Engine that shares e's immutable configuration - the
+// discovered method tables (verbs, resultVerbs, actionVerbs, contextVerbs),
+// grammar, parser, dsl, hooks, wildcard, runtime, logger, argument bounds,
+// argument schemas and strict mode, argument transformer, and grammar version
+// constraint - without re-running reflection. These are safe to share because
+// nothing after NewEngine mutates them except the explicit Register*Method,
+// SetArgBounds and SetArgSchema calls, which callers are expected to make
+// before handing an Engine out for cloning.
+//
+// Request-scoped state is NOT shared: the clone gets its own copy of the
+// allow/deny lists and its own SymbolTable, and starts with no active
+// context, so concurrent requests built from the same base Engine can set
+// their own allow-list or bind their own symbols without racing or leaking
+// state into each other. The clone also gets its own empty parse cache
+// rather than a copy of e's sync.Map (copying a sync.Map by value is
+// unsafe); it simply repopulates its cache the first time each source
+// string is parsed.
+//
+// Clone itself only reads from e, so it is safe to call concurrently with
+// other reads of e; it must not run concurrently with calls that mutate e's
+// method tables (Allow, Deny, RegisterMethod, and friends).
+func (e *Engine) Clone() *Engine {
+	clone := &Engine{
+		grammar:         e.grammar,
+		parser:          e.parser,
+		dsl:             e.dsl,
+		verbs:           e.verbs,
+		resultVerbs:     e.resultVerbs,
+		actionVerbs:     e.actionVerbs,
+		contextVerbs:    e.contextVerbs,
+		beforeHooks:     e.beforeHooks,
+		afterHooks:      e.afterHooks,
+		eventHooks:      e.eventHooks,
+		collectErrs:     e.collectErrs,
+		callTimeout:     e.callTimeout,
+		retryMax:        e.retryMax,
+		retryBackoff:    e.retryBackoff,
+		wildcard:        e.wildcard,
+		caseSensitive:   e.caseSensitive,
+		coerceArgs:      e.coerceArgs,
+		runtime:         e.runtime,
+		contextDefaults: e.contextDefaults,
+		helpEnabled:     e.helpEnabled,
+		trackCurrent:    e.trackCurrent,
+		logger:          e.logger,
+
+		argBounds:                e.argBounds,
+		grammarVersionConstraint: e.grammarVersionConstraint,
+		argTransformer:           e.argTransformer,
+		strictArgs:               e.strictArgs,
+		argSchemas:               e.argSchemas,
+	}
+	clone.argsPool.New = func() interface{} { return make(Args) }
+	if e.allowList != nil {
+		clone.allowList = make(map[string]bool, len(e.allowList))
+		for k, v := range e.allowList {
+			clone.allowList[k] = v
+		}
+	}
+	if e.denyList != nil {
+		clone.denyList = make(map[string]bool, len(e.denyList))
+		for k, v := range e.denyList {
+			clone.denyList[k] = v
+		}
+	}
+	if e.symbols != nil {
+		clone.symbols = NewSymbolTable()
+	}
+	return clone
+}
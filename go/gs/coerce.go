@@ -0,0 +1,63 @@
+package gs
+
+import "strconv"
+
+// coerceArgKey is an internal reserved Args key the Engine sets before
+// dispatch when SetCoerceArgs(true) is in effect, so GetString and GetNumber
+// know whether to fall back to lenient coercion without every DSL method
+// needing a reference back to its Engine. Its leading NUL byte keeps it from
+// ever colliding with a real argument name; SortedKeys and Each both skip
+// it.
+const coerceArgKey = "\x00coerce"
+
+// GetString returns the string value of argument name. A ValueString is
+// returned directly. When the Engine was configured with
+// SetCoerceArgs(true), a ValueNumber or ValueBool is also accepted and
+// stringified (e.g. count=3 satisfies GetString("count") as "3"); otherwise
+// only a ValueString satisfies the lookup.
+func (a Args) GetString(name string) (string, bool) {
+	v, ok := a[name]
+	if !ok {
+		return "", false
+	}
+	switch v.Kind {
+	case ValueString:
+		return v.Str, true
+	case ValueNumber, ValueBool:
+		if a.coerceEnabled() {
+			return v.String(), true
+		}
+	}
+	return "", false
+}
+
+// GetNumber returns the numeric value of argument name. A ValueNumber is
+// returned directly. When the Engine was configured with
+// SetCoerceArgs(true), a ValueString that parses as a number is also
+// accepted (e.g. count="3" satisfies GetNumber("count") as 3); a string that
+// doesn't parse as a number still fails. Without coercion, only a
+// ValueNumber satisfies the lookup.
+func (a Args) GetNumber(name string) (float64, bool) {
+	v, ok := a[name]
+	if !ok {
+		return 0, false
+	}
+	if v.Kind == ValueNumber {
+		return v.Num, true
+	}
+	if v.Kind == ValueString && a.coerceEnabled() {
+		n, err := strconv.ParseFloat(v.Str, 64)
+		if err != nil {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
+
+// coerceEnabled reports whether the Engine that dispatched this call was
+// configured with SetCoerceArgs(true).
+func (a Args) coerceEnabled() bool {
+	v, ok := a[coerceArgKey]
+	return ok && v.Kind == ValueBool && v.Bool
+}
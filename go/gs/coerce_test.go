@@ -0,0 +1,85 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+type coerceDSL struct {
+	gotCount float64
+	gotOK    bool
+}
+
+func (d *coerceDSL) Repeat(args Args) error {
+	d.gotCount, d.gotOK = args.GetNumber("count")
+	return nil
+}
+
+type coerceStringDSL struct {
+	gotName string
+	gotOK   bool
+}
+
+func (d *coerceStringDSL) Label(args Args) error {
+	d.gotName, d.gotOK = args.GetString("name")
+	return nil
+}
+
+func TestCoerceArgsStringToNumber(t *testing.T) {
+	dsl := &coerceDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetCoerceArgs(true)
+	if err := e.Execute(context.Background(), `repeat(count="3")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !dsl.gotOK || dsl.gotCount != 3 {
+		t.Errorf("GetNumber = (%v, %v), want (3, true)", dsl.gotCount, dsl.gotOK)
+	}
+}
+
+func TestCoerceArgsNumberToString(t *testing.T) {
+	dsl := &coerceStringDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetCoerceArgs(true)
+	if err := e.Execute(context.Background(), `label(name=5)`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if !dsl.gotOK || dsl.gotName != "5" {
+		t.Errorf("GetString = (%q, %v), want (\"5\", true)", dsl.gotName, dsl.gotOK)
+	}
+}
+
+func TestCoerceArgsOffByDefaultRejectsTypeMismatch(t *testing.T) {
+	dsl := &coerceDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Execute(context.Background(), `repeat(count="3")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if dsl.gotOK {
+		t.Errorf("GetNumber succeeded on a numeric string with coercion off, want (0, false)")
+	}
+}
+
+func TestCoerceArgsFailsOnNonNumericString(t *testing.T) {
+	dsl := &coerceDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetCoerceArgs(true)
+	if err := e.Execute(context.Background(), `repeat(count="lots")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if dsl.gotOK {
+		t.Errorf("GetNumber succeeded on a non-numeric string, want (0, false)")
+	}
+}
@@ -0,0 +1,57 @@
+package gs
+
+import "testing"
+
+func TestParseSkipsHashComments(t *testing.T) {
+	p := &DefaultParser{}
+	chain, err := p.Parse(`
+# start the track
+play(track="intro")
+`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 || chain.Calls[0].Name != "play" {
+		t.Fatalf("Calls = %+v, want a single play() call", chain.Calls)
+	}
+}
+
+func TestParseSkipsSlashSlashComments(t *testing.T) {
+	p := &DefaultParser{}
+	chain, err := p.Parse(`
+// start the track
+play(track="intro")
+`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 || chain.Calls[0].Name != "play" {
+		t.Fatalf("Calls = %+v, want a single play() call", chain.Calls)
+	}
+}
+
+func TestParseSkipsTrailingComment(t *testing.T) {
+	p := &DefaultParser{}
+	chain, err := p.Parse(`play(track="intro") # and loop it`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 || chain.Calls[0].Name != "play" {
+		t.Fatalf("Calls = %+v, want a single play() call", chain.Calls)
+	}
+}
+
+func TestParsePreservesHashInsideString(t *testing.T) {
+	p := &DefaultParser{}
+	chain, err := p.Parse(`play(track="intro #1")`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 {
+		t.Fatalf("Calls = %+v, want a single call", chain.Calls)
+	}
+	got := chain.Calls[0].Args[0].Value.Str
+	if got != "intro #1" {
+		t.Errorf("track = %q, want %q", got, "intro #1")
+	}
+}
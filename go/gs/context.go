@@ -0,0 +1,57 @@
+package gs
+
+// Context carries state between Actions when using the internal two-layer
+// architecture.
+type Context struct {
+	Data map[string]interface{}
+}
+
+// NewContext returns an empty Context ready to use.
+func NewContext() *Context {
+	return &Context{Data: make(map[string]interface{})}
+}
+
+// Get returns the value stored under key, if any.
+func (c *Context) Get(key string) (interface{}, bool) {
+	v, ok := c.Data[key]
+	return v, ok
+}
+
+// Set stores value under key.
+func (c *Context) Set(key string, value interface{}) {
+	c.Data[key] = value
+}
+
+// Clone returns a new Context with a shallow copy of c's data: safe to
+// mutate independently of c, though values that are themselves reference
+// types (maps, slices, pointers) are still shared.
+func (c *Context) Clone() *Context {
+	clone := NewContext()
+	for k, v := range c.Data {
+		clone.Data[k] = v
+	}
+	return clone
+}
+
+// Merge copies every entry of other into c, overwriting any existing keys.
+func (c *Context) Merge(other *Context) {
+	if other == nil {
+		return
+	}
+	for k, v := range other.Data {
+		c.Data[k] = v
+	}
+}
+
+// GetAs retrieves the value under key and type-asserts it to T. It is a
+// free function rather than a method because Go methods cannot take their
+// own type parameters. ok is false when key is missing or holds a value of
+// a different type.
+func GetAs[T any](c *Context, key string) (value T, ok bool) {
+	v, found := c.Get(key)
+	if !found {
+		return value, false
+	}
+	typed, ok := v.(T)
+	return typed, ok
+}
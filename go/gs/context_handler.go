@@ -0,0 +1,97 @@
+package gs
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ContextHandler is like VerbHandler, except it also receives the Engine's
+// active Context (see SetContext), for methods that need state set earlier
+// in a chain (e.g. a "current tempo") without it being re-passed as an
+// explicit argument on every call.
+type ContextHandler func(ctx *Context, args Args) error
+
+var contextHandlerType = reflect.TypeOf((func(*Context, Args) error)(nil))
+
+// SetContext sets the Context made available to ContextHandler methods.
+// Passing nil clears it; contextVerb methods called with no active Context
+// run with ctx nil and, when WithContextDefaults is set, skip default
+// injection entirely.
+func (e *Engine) SetContext(ctx *Context) {
+	e.activeContext = ctx
+}
+
+// WithContextDefaults enables default-argument injection for ContextHandler
+// methods: before dispatch, any named argument the call didn't explicitly
+// provide is filled in from a same-named key in the active Context (see
+// SetContext), if that key holds a Value. Explicitly-provided arguments are
+// never overridden. It has no effect on VerbHandler, ResultHandler, or
+// ActionHandler methods.
+func WithContextDefaults() EngineOption {
+	return func(e *Engine) { e.contextDefaults = true }
+}
+
+// discoverContextMethods registers every method of dsl matching
+// func(*Context, Args) error as a ContextHandler, the same way
+// discoverMethods registers func(Args) error methods as VerbHandlers,
+// including the same collision detection for methods that register the same
+// snake_case name.
+func (e *Engine) discoverContextMethods(dsl interface{}) error {
+	v := reflect.ValueOf(dsl)
+	t := v.Type()
+	e.contextVerbsMu.Lock()
+	defer e.contextVerbsMu.Unlock()
+	claimedBy := make(map[string]string, t.NumMethod())
+	var collisions []string
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		methodValue := v.Method(i)
+		if methodValue.Type() != contextHandlerType {
+			continue
+		}
+		key := e.methodKey(toSnakeCase(m.Name))
+		if first, ok := claimedBy[key]; ok {
+			collisions = append(collisions, fmt.Sprintf("%q (from %s and %s)", key, first, m.Name))
+			continue
+		}
+		claimedBy[key] = m.Name
+		handler := methodValue.Interface().(func(*Context, Args) error)
+		e.contextVerbs[key] = ContextHandler(handler)
+	}
+	collisions = append(collisions, e.ambiguousEmbeddedCollisions(dsl, contextHandlerType)...)
+	return collisionError(collisions)
+}
+
+// RegisterContextMethod registers handler under name as a ContextHandler,
+// overwriting any ContextHandler already registered under that name. A
+// VerbHandler, ResultHandler or ActionHandler registered under the same name
+// is left in place and still takes precedence at dispatch.
+func (e *Engine) RegisterContextMethod(name string, handler ContextHandler) {
+	e.contextVerbsMu.Lock()
+	defer e.contextVerbsMu.Unlock()
+	e.contextVerbs[e.methodKey(name)] = handler
+}
+
+func (e *Engine) contextVerb(name string) (ContextHandler, bool) {
+	e.contextVerbsMu.RLock()
+	defer e.contextVerbsMu.RUnlock()
+	handler, ok := e.contextVerbs[e.methodKey(name)]
+	return handler, ok
+}
+
+// applyContextDefaults fills any named argument missing from args with the
+// same-named key from e.activeContext, when that key holds a Value. It never
+// overrides an argument the call already set.
+func (e *Engine) applyContextDefaults(args Args) {
+	if !e.contextDefaults || e.activeContext == nil {
+		return
+	}
+	for name, v := range e.activeContext.Data {
+		if _, set := args[name]; set {
+			continue
+		}
+		if value, ok := v.(Value); ok {
+			args[name] = value
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package gs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CoverageReport summarizes which verbs a grammar's "method" rule declares
+// were actually exercised by a corpus of example/test programs (see
+// GrammarCoverage).
+type CoverageReport struct {
+	// DeclaredVerbs is every verb name the grammar's "method" rule declares,
+	// sorted.
+	DeclaredVerbs []string
+	// CoveredVerbs is the subset of DeclaredVerbs called by at least one of
+	// the given programs, sorted.
+	CoveredVerbs []string
+	// UncoveredVerbs is the subset of DeclaredVerbs no program called,
+	// sorted. An author growing their example corpus should aim to shrink
+	// this to nil.
+	UncoveredVerbs []string
+	// Percentage is len(CoveredVerbs) as a percentage of len(DeclaredVerbs),
+	// from 0 to 100. It is 100 when the grammar declares no verbs, since
+	// there is nothing left to cover.
+	Percentage float64
+}
+
+// methodRulePattern matches a Lark "method" rule declaration, e.g.
+// `method: "play" | "stop" | "pause"`, the shape EffectiveGrammar and
+// Grammar School's other generated grammars use to restrict the method
+// rule to a fixed set of verb names.
+var methodRulePattern = regexp.MustCompile(`(?m)^\s*method\s*:\s*(.+)$`)
+
+// extractMethodNames returns the quoted verb-name alternatives of grammar's
+// "method" rule, e.g. ["pause", "play", "stop"] for
+// `method: "play" | "stop" | "pause"`. It errors if grammar has no "method"
+// rule, or the rule has no quoted alternatives to extract.
+func extractMethodNames(grammar string) ([]string, error) {
+	match := methodRulePattern.FindStringSubmatch(CleanGrammarForCFG(grammar))
+	if match == nil {
+		return nil, fmt.Errorf(`gs: grammar has no "method" rule`)
+	}
+	var names []string
+	for _, alt := range strings.Split(match[1], "|") {
+		alt = strings.TrimSpace(alt)
+		if !strings.HasPrefix(alt, `"`) {
+			continue
+		}
+		name, err := strconv.Unquote(alt)
+		if err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf(`gs: "method" rule declares no quoted verb names`)
+	}
+	return names, nil
+}
+
+// GrammarCoverage parses each of programs against grammar and reports which
+// of the verbs grammar's "method" rule declares were actually called by at
+// least one of them, and which were never hit. DSL authors use it to check
+// that an example/test corpus exercises every verb the model might emit,
+// before trusting that corpus to catch a regression. It builds on
+// NewEBNFParser, the same grammar-driven parser CFG tooling uses, so
+// coverage reflects exactly what the grammar (not the Go DSL's reflection-
+// discovered methods) allows.
+func GrammarCoverage(grammar string, programs []string) (CoverageReport, error) {
+	declared, err := extractMethodNames(grammar)
+	if err != nil {
+		return CoverageReport{}, err
+	}
+	parser, err := NewEBNFParser(CleanGrammarForCFG(grammar))
+	if err != nil {
+		return CoverageReport{}, fmt.Errorf("gs: building parser from grammar: %w", err)
+	}
+	exercised := make(map[string]bool)
+	for i, program := range programs {
+		for _, statement := range splitStatements(program) {
+			chain, err := parser.Parse(statement)
+			if err != nil {
+				return CoverageReport{}, fmt.Errorf("gs: program %d: %w", i, err)
+			}
+			for _, call := range chain.Calls {
+				exercised[call.Name] = true
+			}
+		}
+	}
+	sort.Strings(declared)
+	report := CoverageReport{DeclaredVerbs: declared}
+	for _, verb := range declared {
+		if exercised[verb] {
+			report.CoveredVerbs = append(report.CoveredVerbs, verb)
+		} else {
+			report.UncoveredVerbs = append(report.UncoveredVerbs, verb)
+		}
+	}
+	if len(declared) > 0 {
+		report.Percentage = 100 * float64(len(report.CoveredVerbs)) / float64(len(declared))
+	} else {
+		report.Percentage = 100
+	}
+	return report, nil
+}
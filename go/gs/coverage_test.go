@@ -0,0 +1,64 @@
+package gs
+
+import "testing"
+
+const coverageGrammar = `
+method: "play" | "stop" | "pause"
+start: call_chain
+call_chain: call ("." call)*
+call: method "(" ")"
+`
+
+func TestGrammarCoverageAllVerbsCovered(t *testing.T) {
+	report, err := GrammarCoverage(coverageGrammar, []string{"play()", "stop()", "pause()"})
+	if err != nil {
+		t.Fatalf("GrammarCoverage: %v", err)
+	}
+	if len(report.UncoveredVerbs) != 0 {
+		t.Errorf("UncoveredVerbs = %v, want none", report.UncoveredVerbs)
+	}
+	if report.Percentage != 100 {
+		t.Errorf("Percentage = %v, want 100", report.Percentage)
+	}
+}
+
+func TestGrammarCoveragePartiallyCovered(t *testing.T) {
+	report, err := GrammarCoverage(coverageGrammar, []string{"play()"})
+	if err != nil {
+		t.Fatalf("GrammarCoverage: %v", err)
+	}
+	if want := []string{"play"}; !stringSlicesEqual(report.CoveredVerbs, want) {
+		t.Errorf("CoveredVerbs = %v, want %v", report.CoveredVerbs, want)
+	}
+	if want := []string{"pause", "stop"}; !stringSlicesEqual(report.UncoveredVerbs, want) {
+		t.Errorf("UncoveredVerbs = %v, want %v", report.UncoveredVerbs, want)
+	}
+	if got, want := report.Percentage, 100.0/3.0; got < want-0.01 || got > want+0.01 {
+		t.Errorf("Percentage = %v, want ~%v", got, want)
+	}
+}
+
+func TestGrammarCoverageNoProgramsLeavesEverythingUncovered(t *testing.T) {
+	report, err := GrammarCoverage(coverageGrammar, nil)
+	if err != nil {
+		t.Fatalf("GrammarCoverage: %v", err)
+	}
+	if want := []string{"pause", "play", "stop"}; !stringSlicesEqual(report.UncoveredVerbs, want) {
+		t.Errorf("UncoveredVerbs = %v, want %v", report.UncoveredVerbs, want)
+	}
+	if report.Percentage != 0 {
+		t.Errorf("Percentage = %v, want 0", report.Percentage)
+	}
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
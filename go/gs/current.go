@@ -0,0 +1,59 @@
+package gs
+
+// currentContextKey is the Context key the Engine stores a chain's "current
+// object" under when WithCurrentObject is in effect.
+const currentContextKey = "_current"
+
+// currentArgKey is an internal reserved Args key the Engine sets before
+// dispatch to smuggle the active Context's currentContextKey value through
+// to Args.Current, the same way coerceArgKey smuggles the coercion flag
+// through to GetString/GetNumber. Its leading NUL byte keeps it from ever
+// colliding with a real argument name; SortedKeys and Each both skip it.
+const currentArgKey = "\x00current"
+
+// WithCurrentObject enables implicit fluent-interface chaining: the Value a
+// ResultHandler method returns is stashed as the chain's "current object"
+// (in the Engine's active Context, see SetContext, under currentContextKey),
+// and every later call - in the same chain or a later one - can read it back
+// via Args.Current, without the caller having to re-pass it as an explicit
+// argument. This models chains like `track(name="A").mute()`, where mute
+// implicitly targets the track the preceding call produced.
+func WithCurrentObject() EngineOption {
+	return func(e *Engine) { e.trackCurrent = true }
+}
+
+// Current returns the chain's current object, as tracked when the Engine
+// was built with WithCurrentObject: the Value the most recently completed
+// ResultHandler call returned. ok is false if tracking is disabled, no
+// result has been produced yet, or no Context is active.
+func (a Args) Current() (Value, bool) {
+	v, ok := a[currentArgKey]
+	return v, ok
+}
+
+// recordCurrent stashes result as the chain's current object when
+// WithCurrentObject is enabled, creating the active Context if there isn't
+// one yet.
+func (e *Engine) recordCurrent(result Value) {
+	if !e.trackCurrent {
+		return
+	}
+	if e.activeContext == nil {
+		e.activeContext = NewContext()
+	}
+	e.activeContext.Set(currentContextKey, result)
+}
+
+// applyCurrentObject injects the chain's current object (see
+// WithCurrentObject) into args under the reserved currentArgKey, for
+// Args.Current to read back.
+func (e *Engine) applyCurrentObject(args Args) {
+	if !e.trackCurrent || e.activeContext == nil {
+		return
+	}
+	if v, ok := e.activeContext.Get(currentContextKey); ok {
+		if value, ok := v.(Value); ok {
+			args[currentArgKey] = value
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package gs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultParser is Grammar School's built-in parser for the default
+// call-chain DSL syntax: call(name=value, ...).call(...). It is used when
+// no other Parser implementation is supplied.
+type DefaultParser struct {
+	// AllowUnitSuffixes enables unit-suffix numeric literals like
+	// "length=8bars" or "delay=250ms": a number immediately followed by a
+	// known suffix (see numberUnits) becomes a single ValueNumber with its
+	// Unit field set, instead of the suffix lexing as a separate,
+	// unexpected identifier token. It defaults to false so DSLs with no
+	// use for units are unaffected.
+	AllowUnitSuffixes bool
+	// AllowRangeLiterals enables integer range literals like "bars=1..4",
+	// which expand to a ValueList of the integers from the first bound to
+	// the second, inclusive. A descending bound (e.g. "4..1") produces a
+	// descending list rather than an error, since step-sequencer DSLs use
+	// that to mean "count down". It defaults to false so DSLs with no use
+	// for ranges are unaffected.
+	AllowRangeLiterals bool
+}
+
+// maxParseDepth bounds how deeply calls and list literals may nest (e.g.
+// a(a(a(...))) or [[[...]]]), so pathological input - untrusted LLM text is
+// the expected source of DSL programs - fails with an error instead of
+// overflowing the goroutine stack.
+const maxParseDepth = 200
+
+// Parse implements Parser. It never panics: malformed input, including
+// invalid UTF-8 and excessively deep nesting, is always reported as an
+// error.
+func (p *DefaultParser) Parse(input string) (chain *CallChain, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			chain, err = nil, fmt.Errorf("gs: parser panicked on malformed input: %v", r)
+		}
+	}()
+	tokens, meta, lexErr := lex(input, p.AllowUnitSuffixes)
+	if lexErr != nil {
+		return nil, lexErr
+	}
+	ps := &parserState{tokens: tokens, allowRanges: p.AllowRangeLiterals}
+	chain, err = ps.parseCallChain()
+	if err != nil {
+		return nil, err
+	}
+	if ps.peek().kind != tokEOF {
+		return nil, fmt.Errorf("gs: unexpected token %q", ps.peek().text)
+	}
+	if len(chain.Calls) > 0 && chain.Calls[0].Name == "version" {
+		v, err := versionPragmaValue(chain.Calls[0])
+		if err != nil {
+			return nil, err
+		}
+		chain.Version = v
+		chain.Calls = chain.Calls[1:]
+	}
+	if len(meta) > 0 && len(chain.Calls) > 0 {
+		chain.Calls[0].Meta = meta
+	}
+	return chain, nil
+}
+
+// versionPragmaValue extracts the declared version string from a leading
+// version("1.2") pragma call. "version" is a reserved statement-leading
+// call name the same way GuardArgName and RepeatArgName are reserved
+// argument names: a DSL cannot register its own "version" method and expect
+// version(...) calls to reach it.
+func versionPragmaValue(call Call) (string, error) {
+	if len(call.Args) != 1 || call.Args[0].Name != "" || call.Args[0].Value.Kind != ValueString {
+		return "", fmt.Errorf("gs: version(...) pragma requires a single string argument, e.g. version(\"1.2\")")
+	}
+	return call.Args[0].Value.Str, nil
+}
+
+type parserState struct {
+	tokens      []token
+	pos         int
+	depth       int
+	allowRanges bool
+}
+
+// enterNesting tracks entry into a recursive construct (a nested call or
+// list literal) and fails once maxParseDepth is exceeded, rather than
+// recursing until the stack overflows. Every call must be paired with
+// leaveNesting, typically via defer.
+func (ps *parserState) enterNesting() error {
+	ps.depth++
+	if ps.depth > maxParseDepth {
+		return fmt.Errorf("gs: exceeded maximum nesting depth (%d)", maxParseDepth)
+	}
+	return nil
+}
+
+func (ps *parserState) leaveNesting() {
+	ps.depth--
+}
+
+func (ps *parserState) peek() token {
+	return ps.tokens[ps.pos]
+}
+
+func (ps *parserState) peekAt(offset int) token {
+	i := ps.pos + offset
+	if i >= len(ps.tokens) {
+		return ps.tokens[len(ps.tokens)-1]
+	}
+	return ps.tokens[i]
+}
+
+func (ps *parserState) next() token {
+	t := ps.tokens[ps.pos]
+	if ps.pos < len(ps.tokens)-1 {
+		ps.pos++
+	}
+	return t
+}
+
+func (ps *parserState) expect(kind tokenKind) (token, error) {
+	t := ps.peek()
+	if t.kind != kind {
+		return token{}, fmt.Errorf("gs: unexpected token %q", t.text)
+	}
+	return ps.next(), nil
+}
+
+func (ps *parserState) parseCallChain() (*CallChain, error) {
+	var calls []Call
+	call, err := ps.parseCall()
+	if err != nil {
+		return nil, err
+	}
+	calls = append(calls, call)
+	for ps.peek().kind == tokDot {
+		ps.next()
+		call, err := ps.parseCall()
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return &CallChain{Calls: calls}, nil
+}
+
+func (ps *parserState) parseCall() (Call, error) {
+	if err := ps.enterNesting(); err != nil {
+		return Call{}, err
+	}
+	defer ps.leaveNesting()
+	name, err := ps.expect(tokIdent)
+	if err != nil {
+		return Call{}, err
+	}
+	if _, err := ps.expect(tokLParen); err != nil {
+		return Call{}, err
+	}
+	var args []Arg
+	for ps.peek().kind != tokRParen {
+		arg, err := ps.parseArg()
+		if err != nil {
+			return Call{}, err
+		}
+		args = append(args, arg)
+		if ps.peek().kind == tokComma {
+			ps.next()
+			continue
+		}
+		break
+	}
+	if _, err := ps.expect(tokRParen); err != nil {
+		return Call{}, err
+	}
+	return Call{Name: name.text, Args: args}, nil
+}
+
+func (ps *parserState) parseArg() (Arg, error) {
+	if ps.peek().kind == tokIdent && ps.peekAt(1).kind == tokEquals {
+		name := ps.next().text
+		ps.next() // '='
+		value, err := ps.parseExpr()
+		if err != nil {
+			return Arg{}, err
+		}
+		return Arg{Name: name, Value: value}, nil
+	}
+	if ps.peek().kind == tokStar {
+		ps.next()
+		value, err := ps.parseExpr()
+		if err != nil {
+			return Arg{}, err
+		}
+		return Arg{Value: value, Spread: true}, nil
+	}
+	value, err := ps.parseExpr()
+	if err != nil {
+		return Arg{}, err
+	}
+	return Arg{Value: value}, nil
+}
+
+// parseExpr handles the lowest-precedence binary operators: + and -.
+func (ps *parserState) parseExpr() (Value, error) {
+	left, err := ps.parseTerm()
+	if err != nil {
+		return Value{}, err
+	}
+	for ps.peek().kind == tokPlus || ps.peek().kind == tokMinus {
+		op := ps.next()
+		right, err := ps.parseTerm()
+		if err != nil {
+			return Value{}, err
+		}
+		if left, err = applyArith(op.text, left, right); err != nil {
+			return Value{}, err
+		}
+	}
+	return left, nil
+}
+
+// parseTerm handles * and /, which bind tighter than + and -.
+func (ps *parserState) parseTerm() (Value, error) {
+	left, err := ps.parseUnary()
+	if err != nil {
+		return Value{}, err
+	}
+	for ps.peek().kind == tokStar || ps.peek().kind == tokSlash {
+		op := ps.next()
+		right, err := ps.parseUnary()
+		if err != nil {
+			return Value{}, err
+		}
+		if left, err = applyArith(op.text, left, right); err != nil {
+			return Value{}, err
+		}
+	}
+	return left, nil
+}
+
+// parseUnary handles the prefix "-" (numeric negation), "+" (numeric
+// identity, accepted so an LLM writing x=+5 isn't rejected), and "!"
+// (boolean negation) operators.
+func (ps *parserState) parseUnary() (Value, error) {
+	switch ps.peek().kind {
+	case tokMinus:
+		ps.next()
+		v, err := ps.parseUnary()
+		if err != nil {
+			return Value{}, err
+		}
+		if v.Kind != ValueNumber {
+			return Value{}, fmt.Errorf("gs: unary - requires a number")
+		}
+		v.Num = -v.Num
+		return v, nil
+	case tokPlus:
+		ps.next()
+		v, err := ps.parseUnary()
+		if err != nil {
+			return Value{}, err
+		}
+		if v.Kind != ValueNumber {
+			return Value{}, fmt.Errorf("gs: unary + requires a number")
+		}
+		return v, nil
+	case tokBang:
+		ps.next()
+		v, err := ps.parseUnary()
+		if err != nil {
+			return Value{}, err
+		}
+		if v.Kind != ValueBool {
+			return Value{}, fmt.Errorf("gs: ! requires a bool")
+		}
+		v.Bool = !v.Bool
+		return v, nil
+	default:
+		return ps.parsePrimary()
+	}
+}
+
+func applyArith(op string, a, b Value) (Value, error) {
+	if a.Kind != ValueNumber || b.Kind != ValueNumber {
+		return Value{}, fmt.Errorf("gs: arithmetic operator %q requires numbers", op)
+	}
+	result := Value{Kind: ValueNumber, IsFloat: a.IsFloat || b.IsFloat}
+	switch op {
+	case "+":
+		result.Num = a.Num + b.Num
+	case "-":
+		result.Num = a.Num - b.Num
+	case "*":
+		result.Num = a.Num * b.Num
+	case "/":
+		if b.Num == 0 {
+			return Value{}, fmt.Errorf("gs: division by zero")
+		}
+		result.Num = a.Num / b.Num
+	}
+	return result, nil
+}
+
+func (ps *parserState) parsePrimary() (Value, error) {
+	t := ps.peek()
+	switch t.kind {
+	case tokNumber:
+		ps.next()
+		numText, unit := splitNumberUnit(t.text)
+		num, err := strconv.ParseFloat(numText, 64)
+		if err != nil {
+			return Value{}, fmt.Errorf("gs: invalid number %q: %w", t.text, err)
+		}
+		if ps.allowRanges && ps.peek().kind == tokRange {
+			if unit != "" {
+				return Value{}, fmt.Errorf("gs: range literal bounds cannot have a unit suffix")
+			}
+			ps.next()
+			endTok, err := ps.expect(tokNumber)
+			if err != nil {
+				return Value{}, fmt.Errorf("gs: range literal: %w", err)
+			}
+			endText, endUnit := splitNumberUnit(endTok.text)
+			if endUnit != "" {
+				return Value{}, fmt.Errorf("gs: range literal bounds cannot have a unit suffix")
+			}
+			end, err := strconv.ParseFloat(endText, 64)
+			if err != nil {
+				return Value{}, fmt.Errorf("gs: invalid number %q: %w", endTok.text, err)
+			}
+			return rangeValue(numText, num, endText, end)
+		}
+		return Value{Kind: ValueNumber, Num: num, IsFloat: strings.ContainsAny(numText, ".eE"), Unit: unit}, nil
+	case tokString:
+		ps.next()
+		return Value{Kind: ValueString, Str: t.text}, nil
+	case tokBool:
+		ps.next()
+		return Value{Kind: ValueBool, Bool: t.text == "true"}, nil
+	case tokNull:
+		ps.next()
+		return Value{Kind: ValueNull}, nil
+	case tokLBracket:
+		if err := ps.enterNesting(); err != nil {
+			return Value{}, err
+		}
+		defer ps.leaveNesting()
+		ps.next()
+		var elems []Value
+		for ps.peek().kind != tokRBracket {
+			elem, err := ps.parseExpr()
+			if err != nil {
+				return Value{}, err
+			}
+			elems = append(elems, elem)
+			if ps.peek().kind == tokComma {
+				ps.next()
+				continue
+			}
+			break
+		}
+		if _, err := ps.expect(tokRBracket); err != nil {
+			return Value{}, err
+		}
+		return Value{Kind: ValueList, List: elems}, nil
+	case tokIdent:
+		if ps.peekAt(1).kind == tokLParen {
+			call, err := ps.parseCall()
+			if err != nil {
+				return Value{}, err
+			}
+			return Value{Kind: ValueCall, Call: &call}, nil
+		}
+		ps.next()
+		return Value{Kind: ValueIdentifier, Str: t.text}, nil
+	default:
+		return Value{}, fmt.Errorf("gs: unexpected token %q in value position", t.text)
+	}
+}
+
+// rangeValue expands a range literal's two integer bounds (e.g. "1..4")
+// into a ValueList of ValueNumbers. A descending bound (start > end)
+// produces a descending list, the documented behavior for step-sequencer
+// DSLs that use a range to mean "count down" (e.g. "4..1" -> [4, 3, 2, 1]).
+// startText/endText are the original literal text, used only to reject
+// fractional bounds like "1.5..4".
+func rangeValue(startText string, start float64, endText string, end float64) (Value, error) {
+	if strings.Contains(startText, ".") || strings.Contains(endText, ".") {
+		return Value{}, fmt.Errorf("gs: range literal bounds must be integers")
+	}
+	s, e := int(start), int(end)
+	var elems []Value
+	if s <= e {
+		for i := s; i <= e; i++ {
+			elems = append(elems, NewNumberValue(float64(i)))
+		}
+	} else {
+		for i := s; i >= e; i-- {
+			elems = append(elems, NewNumberValue(float64(i)))
+		}
+	}
+	return Value{Kind: ValueList, List: elems}, nil
+}
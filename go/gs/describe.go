@@ -0,0 +1,72 @@
+package gs
+
+import "encoding/json"
+
+// MethodInfo describes one method registered with an Engine, for
+// introspection, documentation generation, or summarizing an Engine's
+// capabilities for a model. See Engine.DescribeMethods.
+type MethodInfo struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	ArgPolicy   string            `json:"arg_policy,omitempty"`
+	Defaults    map[string]string `json:"defaults,omitempty"`
+}
+
+// argPolicyName renders an ArgPolicyKind as the string DescribeMethods uses
+// in MethodInfo.ArgPolicy.
+func argPolicyName(policy ArgPolicyKind) string {
+	switch policy {
+	case ArgsKeywordOnly:
+		return "keyword_only"
+	case ArgsPositionalOnly:
+		return "positional_only"
+	default:
+		return "any"
+	}
+}
+
+// DescribeMethods returns a MethodInfo for every method currently registered
+// with the Engine (see Methods), in the same sorted order, pulling in each
+// method's description (MethodDescriber), argument policy
+// (ArgPolicyProvider), and default arguments (ArgDefaulter) when the DSL
+// implements those optional interfaces.
+func (e *Engine) DescribeMethods() []MethodInfo {
+	names := e.Methods()
+	infos := make([]MethodInfo, len(names))
+	policyProvider, hasPolicy := e.dsl.(ArgPolicyProvider)
+	defaulter, hasDefaults := e.dsl.(ArgDefaulter)
+	for i, name := range names {
+		info := MethodInfo{Name: name, Description: e.MethodDescription(name)}
+		if hasPolicy {
+			info.ArgPolicy = argPolicyName(policyProvider.ArgPolicy(name))
+		}
+		if hasDefaults {
+			if defaults := defaulter.DefaultArgs(name); len(defaults) > 0 {
+				info.Defaults = make(map[string]string, len(defaults))
+				for argName, value := range defaults {
+					info.Defaults[argName] = value.String()
+				}
+			}
+		}
+		infos[i] = info
+	}
+	return infos
+}
+
+// dslDescription is the payload DescribeJSON marshals.
+type dslDescription struct {
+	Grammar string       `json:"grammar,omitempty"`
+	Methods []MethodInfo `json:"methods"`
+}
+
+// DescribeJSON returns a machine-readable manifest of the Engine's full DSL
+// surface: the grammar it was constructed with and a MethodInfo for every
+// registered method (see DescribeMethods). This is meant for building
+// dynamic UIs, documentation sites, or a compact capability summary to hand
+// to a model alongside its prompt.
+func (e *Engine) DescribeJSON() ([]byte, error) {
+	return json.Marshal(dslDescription{
+		Grammar: e.grammar,
+		Methods: e.DescribeMethods(),
+	})
+}
@@ -0,0 +1,180 @@
+package gs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChainDiffKind identifies how a call differs between two CallChains.
+type ChainDiffKind int
+
+const (
+	// DiffAdded means the call only exists in the second chain.
+	DiffAdded ChainDiffKind = iota
+	// DiffRemoved means the call only exists in the first chain.
+	DiffRemoved
+	// DiffChanged means a call at the same position exists in both chains
+	// but its name or arguments differ.
+	DiffChanged
+)
+
+// String returns "added", "removed", or "changed".
+func (k ChainDiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	case DiffChanged:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// ArgDiff describes how a single argument differs between two calls at the
+// same chain position. Before is nil when the argument only exists in the
+// second call; After is nil when it only exists in the first.
+type ArgDiff struct {
+	Name   string
+	Before *Value
+	After  *Value
+}
+
+// String renders d as e.g. "x: 1 -> 2", "+y=2" (added), or "-z=3" (removed).
+func (d ArgDiff) String() string {
+	switch {
+	case d.Before == nil:
+		return fmt.Sprintf("+%s=%s", d.Name, d.After.String())
+	case d.After == nil:
+		return fmt.Sprintf("-%s=%s", d.Name, d.Before.String())
+	default:
+		return fmt.Sprintf("%s: %s -> %s", d.Name, d.Before.String(), d.After.String())
+	}
+}
+
+// ChainDiff describes how the call at Index differs between two CallChains,
+// as reported by DiffCallChains.
+type ChainDiff struct {
+	Kind   ChainDiffKind
+	Index  int
+	Before Call
+	After  Call
+	// Args holds the per-argument deltas when Kind is DiffChanged and the
+	// call's name is unchanged; empty otherwise.
+	Args []ArgDiff
+}
+
+// String renders d as a single human-readable line, e.g.
+// "~ calls[0] point(x: 1 -> 2)" or "+ calls[2] draw()".
+func (d ChainDiff) String() string {
+	switch d.Kind {
+	case DiffAdded:
+		return fmt.Sprintf("+ calls[%d] %s", d.Index, d.After.String())
+	case DiffRemoved:
+		return fmt.Sprintf("- calls[%d] %s", d.Index, d.Before.String())
+	default:
+		if d.Before.Name != d.After.Name {
+			return fmt.Sprintf("~ calls[%d] %s -> %s", d.Index, d.Before.String(), d.After.String())
+		}
+		args := make([]string, len(d.Args))
+		for i, a := range d.Args {
+			args[i] = a.String()
+		}
+		return fmt.Sprintf("~ calls[%d] %s(%s)", d.Index, d.Before.Name, strings.Join(args, ", "))
+	}
+}
+
+// DiffCallChains compares a and b call-by-call in order and reports every
+// position where they differ: calls only in b (DiffAdded), calls only in a
+// (DiffRemoved), or calls present in both but with a different name or
+// arguments (DiffChanged). Positions where the calls are identical are
+// omitted. The comparison is purely positional rather than a minimal edit
+// script, so inserting a call in the middle of a chain shows up as a run of
+// DiffChanged entries instead of a single DiffAdded one; for the common case
+// of comparing an LLM's retried output against its first attempt, chains
+// usually only grow, shrink, or tweak arguments in place, so this still
+// reads cleanly.
+func DiffCallChains(a, b *CallChain) []ChainDiff {
+	var aCalls, bCalls []Call
+	if a != nil {
+		aCalls = a.Calls
+	}
+	if b != nil {
+		bCalls = b.Calls
+	}
+	n := len(aCalls)
+	if len(bCalls) > n {
+		n = len(bCalls)
+	}
+	var diffs []ChainDiff
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(aCalls):
+			diffs = append(diffs, ChainDiff{Kind: DiffAdded, Index: i, After: bCalls[i]})
+		case i >= len(bCalls):
+			diffs = append(diffs, ChainDiff{Kind: DiffRemoved, Index: i, Before: aCalls[i]})
+		case !aCalls[i].Equal(bCalls[i]):
+			diffs = append(diffs, ChainDiff{
+				Kind:   DiffChanged,
+				Index:  i,
+				Before: aCalls[i],
+				After:  bCalls[i],
+				Args:   diffArgs(aCalls[i].Args, bCalls[i].Args),
+			})
+		}
+	}
+	return diffs
+}
+
+// diffArgs compares two argument lists by name (falling back to
+// PositionalArgName for unnamed arguments), returning one ArgDiff per name
+// whose value differs or that is only present on one side, sorted by name
+// for stable output.
+func diffArgs(a, b []Arg) []ArgDiff {
+	before := argsByKey(a)
+	after := argsByKey(b)
+	names := make(map[string]bool, len(before)+len(after))
+	for name := range before {
+		names[name] = true
+	}
+	for name := range after {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	var diffs []ArgDiff
+	for _, name := range sorted {
+		bv, bok := before[name]
+		av, aok := after[name]
+		switch {
+		case !bok:
+			diffs = append(diffs, ArgDiff{Name: name, After: &av})
+		case !aok:
+			diffs = append(diffs, ArgDiff{Name: name, Before: &bv})
+		case !bv.Equal(av):
+			diffs = append(diffs, ArgDiff{Name: name, Before: &bv, After: &av})
+		}
+	}
+	return diffs
+}
+
+// argsByKey maps args by Name, assigning positional (unnamed) ones
+// PositionalArgName(i) for their index i among the positional arguments.
+func argsByKey(args []Arg) map[string]Value {
+	byKey := make(map[string]Value, len(args))
+	pos := 0
+	for _, a := range args {
+		name := a.Name
+		if name == "" {
+			name = PositionalArgName(pos)
+			pos++
+		}
+		byKey[name] = a.Value
+	}
+	return byKey
+}
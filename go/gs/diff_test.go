@@ -0,0 +1,53 @@
+package gs
+
+import "testing"
+
+func TestDiffCallChainsAddedAndRemoved(t *testing.T) {
+	a := &CallChain{Calls: []Call{{Name: "play"}}}
+	b := &CallChain{Calls: []Call{{Name: "play"}, {Name: "stop"}}}
+
+	diffs := DiffCallChains(a, b)
+	if len(diffs) != 1 || diffs[0].Kind != DiffAdded || diffs[0].Index != 1 {
+		t.Fatalf("diffs = %+v, want a single DiffAdded at index 1", diffs)
+	}
+
+	diffs = DiffCallChains(b, a)
+	if len(diffs) != 1 || diffs[0].Kind != DiffRemoved || diffs[0].Index != 1 {
+		t.Fatalf("diffs = %+v, want a single DiffRemoved at index 1", diffs)
+	}
+}
+
+func TestDiffCallChainsChangedArgs(t *testing.T) {
+	a := &CallChain{Calls: []Call{
+		{Name: "point", Args: []Arg{{Name: "x", Value: NewNumberValue(1)}}},
+	}}
+	b := &CallChain{Calls: []Call{
+		{Name: "point", Args: []Arg{{Name: "x", Value: NewNumberValue(2)}}},
+	}}
+	diffs := DiffCallChains(a, b)
+	if len(diffs) != 1 || diffs[0].Kind != DiffChanged {
+		t.Fatalf("diffs = %+v, want a single DiffChanged", diffs)
+	}
+	if len(diffs[0].Args) != 1 || diffs[0].Args[0].Name != "x" {
+		t.Fatalf("Args = %+v, want a single x arg delta", diffs[0].Args)
+	}
+}
+
+func TestDiffCallChainsIdenticalProducesNoDiffs(t *testing.T) {
+	a := &CallChain{Calls: []Call{{Name: "play"}}}
+	b := &CallChain{Calls: []Call{{Name: "play"}}}
+	if diffs := DiffCallChains(a, b); len(diffs) != 0 {
+		t.Errorf("diffs = %+v, want none for identical chains", diffs)
+	}
+}
+
+func TestChainDiffStringRendersEachKind(t *testing.T) {
+	added := ChainDiff{Kind: DiffAdded, Index: 2, After: Call{Name: "draw"}}
+	if got, want := added.String(), `+ calls[2] draw()`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	removed := ChainDiff{Kind: DiffRemoved, Index: 1, Before: Call{Name: "draw"}}
+	if got, want := removed.String(), `- calls[1] draw()`; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
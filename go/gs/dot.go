@@ -0,0 +1,40 @@
+package gs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DOT renders cc as a Graphviz DOT digraph for debugging: one node per call
+// in the chain, connected in order by "next" edges, plus a node for every
+// nested call passed as an argument, connected to its parent by a dashed
+// edge labeled with the argument name.
+func (cc *CallChain) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph CallChain {\n")
+	counter := 0
+	var emit func(call Call) string
+	emit = func(call Call) string {
+		id := fmt.Sprintf("call%d", counter)
+		counter++
+		sb.WriteString(fmt.Sprintf("  %s [label=%s];\n", id, strconv.Quote(call.String())))
+		for _, arg := range call.Args {
+			if arg.Value.Kind == ValueCall && arg.Value.Call != nil {
+				childID := emit(*arg.Value.Call)
+				sb.WriteString(fmt.Sprintf("  %s -> %s [label=%s, style=dashed];\n", id, childID, strconv.Quote(arg.Name)))
+			}
+		}
+		return id
+	}
+	var prevID string
+	for _, call := range cc.Calls {
+		id := emit(call)
+		if prevID != "" {
+			sb.WriteString(fmt.Sprintf("  %s -> %s [label=\"next\"];\n", prevID, id))
+		}
+		prevID = id
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package gs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallChainDOTLinearChain(t *testing.T) {
+	cc := &CallChain{Calls: []Call{
+		{Name: "play"},
+		{Name: "stop"},
+	}}
+	dot := cc.DOT()
+	if !strings.HasPrefix(dot, "digraph CallChain {\n") {
+		t.Fatalf("DOT() = %q, want it to start with the digraph header", dot)
+	}
+	if !strings.Contains(dot, `label="play()"`) || !strings.Contains(dot, `label="stop()"`) {
+		t.Errorf("DOT() = %q, want a labeled node for each call", dot)
+	}
+	if !strings.Contains(dot, `[label="next"]`) {
+		t.Errorf("DOT() = %q, want a \"next\" edge between the two calls", dot)
+	}
+}
+
+func TestCallChainDOTNestedCallArgument(t *testing.T) {
+	cc := &CallChain{Calls: []Call{
+		{Name: "draw", Args: []Arg{
+			{Name: "at", Value: NewCallValue(Call{Name: "point"})},
+		}},
+	}}
+	dot := cc.DOT()
+	if !strings.Contains(dot, `label="point()"`) {
+		t.Errorf("DOT() = %q, want a node for the nested point() call", dot)
+	}
+	if !strings.Contains(dot, `style=dashed`) {
+		t.Errorf("DOT() = %q, want a dashed edge to the nested call", dot)
+	}
+	if !strings.Contains(dot, `label="at"`) {
+		t.Errorf("DOT() = %q, want the nested edge labeled with its argument name", dot)
+	}
+}
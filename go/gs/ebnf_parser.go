@@ -0,0 +1,407 @@
+package gs
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// EBNFParser is a Parser built from a restricted EBNF/Lark subset via
+// NewEBNFParser, for DSL authors who want to constrain or extend the
+// accepted surface syntax declaratively instead of writing a Go lexer and
+// recursive-descent parser by hand.
+//
+// Supported subset, one rule per line:
+//
+//	start: call_chain
+//	call_chain: call ("." call)*
+//	call: NAME "(" ")"
+//
+//   - A rule is `name: alternative ("|" alternative)*`.
+//   - An alternative is a sequence of terms separated by whitespace.
+//   - A term is a quoted literal (e.g. "(" or ".", or a word such as "play",
+//     which matches an identifier with exactly that text), an UPPERCASE built-in
+//     terminal (NAME, NUMBER, STRING, BOOL, NULL), or a lowercase reference
+//     to another rule, optionally parenthesized into a group.
+//   - Any term or group may be suffixed with "*" (zero or more), "+" (one or
+//     more), or "?" (optional).
+//   - Lines that are blank, or start with "#" or "//", are ignored.
+//
+// The grammar must define a "start" rule. EBNFParser does not redefine how
+// a CallChain is built from matched tokens - it only constrains which
+// programs are accepted before handing them to DefaultParser's existing
+// call-chain semantics, so custom grammars stay round-trippable with the
+// same Engine dispatch as the built-in syntax.
+type EBNFParser struct {
+	grammar *ebnfGrammar
+}
+
+// NewEBNFParser builds an EBNFParser from grammar. It returns an error if
+// grammar cannot be parsed as the supported subset, or defines no "start"
+// rule.
+func NewEBNFParser(grammar string) (Parser, error) {
+	g, err := parseEBNFGrammar(grammar)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := g.rules["start"]; !ok {
+		return nil, fmt.Errorf("gs: EBNF grammar has no \"start\" rule")
+	}
+	return &EBNFParser{grammar: g}, nil
+}
+
+// Parse implements Parser: it checks input against the grammar's "start"
+// rule, then builds the CallChain using the same semantics as
+// DefaultParser.Parse.
+func (p *EBNFParser) Parse(input string) (*CallChain, error) {
+	tokens, _, err := lex(input, false)
+	if err != nil {
+		return nil, err
+	}
+	end, ok := p.grammar.match("start", tokens, 0)
+	if !ok || tokens[end].kind != tokEOF {
+		return nil, fmt.Errorf("gs: input does not match the EBNF grammar's \"start\" rule")
+	}
+	return (&DefaultParser{}).Parse(input)
+}
+
+// ebnfTermKind identifies what an ebnfTerm matches against the DSL token
+// stream.
+type ebnfTermKind int
+
+const (
+	ebnfLiteral ebnfTermKind = iota
+	ebnfTerminal
+	ebnfRuleRef
+	ebnfGroup
+)
+
+// ebnfTerm is a single element of an ebnfAlt, e.g. "(" , NAME, call, or a
+// parenthesized group, optionally repeated via rep.
+type ebnfTerm struct {
+	kind  ebnfTermKind
+	text  string // literal text, terminal name, or rule name
+	group []ebnfAlt
+	rep   byte // 0, '*', '+', or '?'
+}
+
+// ebnfAlt is one alternative right-hand side of a rule: a sequence of terms
+// that must all match in order.
+type ebnfAlt []ebnfTerm
+
+// ebnfGrammar is a parsed EBNF/Lark-subset grammar, ready to match DSL token
+// streams via match.
+type ebnfGrammar struct {
+	rules map[string][]ebnfAlt
+}
+
+// ebnfLiteralTokens maps the quoted literals NewEBNFParser's subset accepts
+// to the tokenKind the default lexer produces for them.
+var ebnfLiteralTokens = map[string]tokenKind{
+	"(": tokLParen,
+	")": tokRParen,
+	",": tokComma,
+	"=": tokEquals,
+	".": tokDot,
+	"+": tokPlus,
+	"-": tokMinus,
+	"*": tokStar,
+	"/": tokSlash,
+	"!": tokBang,
+	"[": tokLBracket,
+	"]": tokRBracket,
+}
+
+// ebnfTerminalTokens maps the built-in UPPERCASE terminal names to the
+// tokenKind the default lexer produces for them.
+var ebnfTerminalTokens = map[string]tokenKind{
+	"NAME":   tokIdent,
+	"NUMBER": tokNumber,
+	"STRING": tokString,
+	"BOOL":   tokBool,
+	"NULL":   tokNull,
+}
+
+// match reports whether the token stream toks, starting at pos, matches
+// rule name, returning the position just past the match. It tries each
+// alternative in order and commits to the first that matches (PEG-style),
+// which is simpler than general context-free matching but sufficient for
+// the supported subset's unambiguous grammars.
+func (g *ebnfGrammar) match(name string, toks []token, pos int) (int, bool) {
+	for _, alt := range g.rules[name] {
+		if end, ok := g.matchSeq(alt, toks, pos); ok {
+			return end, true
+		}
+	}
+	return pos, false
+}
+
+func (g *ebnfGrammar) matchSeq(seq ebnfAlt, toks []token, pos int) (int, bool) {
+	for _, term := range seq {
+		end, ok := g.matchTerm(term, toks, pos)
+		if !ok {
+			return pos, false
+		}
+		pos = end
+	}
+	return pos, true
+}
+
+func (g *ebnfGrammar) matchTerm(term ebnfTerm, toks []token, pos int) (int, bool) {
+	switch term.rep {
+	case '*':
+		for {
+			end, ok := g.matchOne(term, toks, pos)
+			if !ok {
+				return pos, true
+			}
+			pos = end
+		}
+	case '+':
+		end, ok := g.matchOne(term, toks, pos)
+		if !ok {
+			return pos, false
+		}
+		pos = end
+		for {
+			end, ok := g.matchOne(term, toks, pos)
+			if !ok {
+				return pos, true
+			}
+			pos = end
+		}
+	case '?':
+		if end, ok := g.matchOne(term, toks, pos); ok {
+			return end, true
+		}
+		return pos, true
+	default:
+		return g.matchOne(term, toks, pos)
+	}
+}
+
+func (g *ebnfGrammar) matchOne(term ebnfTerm, toks []token, pos int) (int, bool) {
+	switch term.kind {
+	case ebnfLiteral:
+		if pos >= len(toks) {
+			return pos, false
+		}
+		if kind, ok := ebnfLiteralTokens[term.text]; ok {
+			if toks[pos].kind != kind {
+				return pos, false
+			}
+			return pos + 1, true
+		}
+		if toks[pos].kind == tokIdent && toks[pos].text == term.text {
+			return pos + 1, true
+		}
+		return pos, false
+	case ebnfTerminal:
+		kind, ok := ebnfTerminalTokens[term.text]
+		if !ok || pos >= len(toks) || toks[pos].kind != kind {
+			return pos, false
+		}
+		return pos + 1, true
+	case ebnfRuleRef:
+		return g.match(term.text, toks, pos)
+	case ebnfGroup:
+		for _, alt := range term.group {
+			if end, ok := g.matchSeq(alt, toks, pos); ok {
+				return end, true
+			}
+		}
+		return pos, false
+	default:
+		return pos, false
+	}
+}
+
+// parseEBNFGrammar parses grammar as the restricted subset EBNFParser
+// documents, one rule per line.
+func parseEBNFGrammar(grammar string) (*ebnfGrammar, error) {
+	g := &ebnfGrammar{rules: make(map[string][]ebnfAlt)}
+	for lineNum, line := range strings.Split(grammar, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+		idx := strings.Index(trimmed, ":")
+		if idx < 0 {
+			return nil, fmt.Errorf("gs: EBNF grammar line %d: missing \":\" in rule definition: %q", lineNum+1, trimmed)
+		}
+		name := strings.TrimSpace(trimmed[:idx])
+		if name == "" {
+			return nil, fmt.Errorf("gs: EBNF grammar line %d: rule has no name", lineNum+1)
+		}
+		toks, err := lexEBNF(trimmed[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("gs: EBNF grammar line %d: %w", lineNum+1, err)
+		}
+		alts, rest, err := parseEBNFAlts(toks)
+		if err != nil {
+			return nil, fmt.Errorf("gs: EBNF grammar line %d: %w", lineNum+1, err)
+		}
+		if len(rest) != 0 {
+			return nil, fmt.Errorf("gs: EBNF grammar line %d: unexpected %q after rule body", lineNum+1, rest[0].text)
+		}
+		g.rules[name] = append(g.rules[name], alts...)
+	}
+	return g, nil
+}
+
+// ebnfTokKind identifies a token in an EBNF rule's right-hand side.
+type ebnfTokKind int
+
+const (
+	ebnfTokLit ebnfTokKind = iota
+	ebnfTokTerm
+	ebnfTokRuleRef
+	ebnfTokLParen
+	ebnfTokRParen
+	ebnfTokStar
+	ebnfTokPlus
+	ebnfTokQuestion
+	ebnfTokPipe
+)
+
+type ebnfTok struct {
+	kind ebnfTokKind
+	text string
+}
+
+// lexEBNF tokenizes one rule's right-hand side.
+func lexEBNF(src string) ([]ebnfTok, error) {
+	var toks []ebnfTok
+	runes := []rune(src)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				j++
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("unterminated literal")
+			}
+			toks = append(toks, ebnfTok{ebnfTokLit, string(runes[i+1 : j])})
+			i = j + 1
+		case r == '(':
+			toks = append(toks, ebnfTok{ebnfTokLParen, "("})
+			i++
+		case r == ')':
+			toks = append(toks, ebnfTok{ebnfTokRParen, ")"})
+			i++
+		case r == '*':
+			toks = append(toks, ebnfTok{ebnfTokStar, "*"})
+			i++
+		case r == '+':
+			toks = append(toks, ebnfTok{ebnfTokPlus, "+"})
+			i++
+		case r == '?':
+			toks = append(toks, ebnfTok{ebnfTokQuestion, "?"})
+			i++
+		case r == '|':
+			toks = append(toks, ebnfTok{ebnfTokPipe, "|"})
+			i++
+		case unicode.IsLetter(r) || r == '_':
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			name := string(runes[i:j])
+			if strings.ToUpper(name) == name {
+				toks = append(toks, ebnfTok{ebnfTokTerm, name})
+			} else {
+				toks = append(toks, ebnfTok{ebnfTokRuleRef, name})
+			}
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return toks, nil
+}
+
+// parseEBNFAlts parses a "|"-separated list of alternatives from toks,
+// returning whatever tokens weren't consumed (the caller checks that's
+// empty once the whole rule line has been parsed).
+func parseEBNFAlts(toks []ebnfTok) ([]ebnfAlt, []ebnfTok, error) {
+	var alts []ebnfAlt
+	for {
+		alt, rest, err := parseEBNFSeq(toks)
+		if err != nil {
+			return nil, nil, err
+		}
+		alts = append(alts, alt)
+		toks = rest
+		if len(toks) == 0 || toks[0].kind != ebnfTokPipe {
+			return alts, toks, nil
+		}
+		toks = toks[1:]
+	}
+}
+
+// parseEBNFSeq parses a sequence of terms up to the next "|", unmatched
+// ")", or end of input.
+func parseEBNFSeq(toks []ebnfTok) (ebnfAlt, []ebnfTok, error) {
+	var seq ebnfAlt
+	for len(toks) > 0 && toks[0].kind != ebnfTokPipe && toks[0].kind != ebnfTokRParen {
+		term, rest, err := parseEBNFTerm(toks)
+		if err != nil {
+			return nil, nil, err
+		}
+		seq = append(seq, term)
+		toks = rest
+	}
+	return seq, toks, nil
+}
+
+// parseEBNFTerm parses one term (a literal, terminal, rule reference, or
+// parenthesized group), including any trailing repetition suffix.
+func parseEBNFTerm(toks []ebnfTok) (ebnfTerm, []ebnfTok, error) {
+	if len(toks) == 0 {
+		return ebnfTerm{}, nil, fmt.Errorf("unexpected end of rule")
+	}
+	var term ebnfTerm
+	switch toks[0].kind {
+	case ebnfTokLit:
+		term = ebnfTerm{kind: ebnfLiteral, text: toks[0].text}
+		toks = toks[1:]
+	case ebnfTokTerm:
+		term = ebnfTerm{kind: ebnfTerminal, text: toks[0].text}
+		toks = toks[1:]
+	case ebnfTokRuleRef:
+		term = ebnfTerm{kind: ebnfRuleRef, text: toks[0].text}
+		toks = toks[1:]
+	case ebnfTokLParen:
+		alts, rest, err := parseEBNFAlts(toks[1:])
+		if err != nil {
+			return ebnfTerm{}, nil, err
+		}
+		if len(rest) == 0 || rest[0].kind != ebnfTokRParen {
+			return ebnfTerm{}, nil, fmt.Errorf("missing closing \")\"")
+		}
+		term = ebnfTerm{kind: ebnfGroup, group: alts}
+		toks = rest[1:]
+	default:
+		return ebnfTerm{}, nil, fmt.Errorf("unexpected token %q", toks[0].text)
+	}
+	if len(toks) > 0 {
+		switch toks[0].kind {
+		case ebnfTokStar:
+			term.rep = '*'
+			toks = toks[1:]
+		case ebnfTokPlus:
+			term.rep = '+'
+			toks = toks[1:]
+		case ebnfTokQuestion:
+			term.rep = '?'
+			toks = toks[1:]
+		}
+	}
+	return term, toks, nil
+}
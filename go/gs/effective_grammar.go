@@ -0,0 +1,75 @@
+package gs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// allMethodNames returns every currently-registered method name across all
+// four handler namespaces (VerbHandler, ResultHandler, ActionHandler,
+// ContextHandler), deduplicated and sorted. Unlike Methods, which only
+// covers VerbHandler, this is what EffectiveGrammar needs to describe
+// everything the Engine can actually dispatch.
+func (e *Engine) allMethodNames() []string {
+	seen := make(map[string]bool)
+	e.verbsMu.RLock()
+	for name := range e.verbs {
+		seen[name] = true
+	}
+	e.verbsMu.RUnlock()
+	e.resultVerbsMu.RLock()
+	for name := range e.resultVerbs {
+		seen[name] = true
+	}
+	e.resultVerbsMu.RUnlock()
+	e.actionVerbsMu.RLock()
+	for name := range e.actionVerbs {
+		seen[name] = true
+	}
+	e.actionVerbsMu.RUnlock()
+	e.contextVerbsMu.RLock()
+	for name := range e.contextVerbs {
+		seen[name] = true
+	}
+	e.contextVerbsMu.RUnlock()
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EffectiveGrammar generates a Lark grammar describing exactly the methods
+// the Engine can currently dispatch, across every handler namespace and
+// including anything added after NewEngine via RegisterMethod,
+// RegisterResultMethod, RegisterActionMethod, RegisterContextMethod, or
+// Alias - as opposed to the static grammar string (if any) passed to
+// NewEngine, which drifts out of sync the moment a method is registered
+// dynamically. It shares its call-chain shape (call_chain, call, args,
+// value, list) with the restricted subset EBNFParser understands, but
+// constrains the "method" rule to the Engine's actual verb names, so a
+// model constrained by this grammar cannot propose a call the Engine would
+// reject as unknown. It errors if the Engine has no registered methods,
+// since a grammar with an empty "method" rule could never match anything.
+func (e *Engine) EffectiveGrammar() (string, error) {
+	methods := e.allMethodNames()
+	if len(methods) == 0 {
+		return "", fmt.Errorf("gs: engine has no registered methods")
+	}
+	alts := make([]string, len(methods))
+	for i, m := range methods {
+		alts[i] = fmt.Sprintf("%q", m)
+	}
+	var b strings.Builder
+	b.WriteString("start: call_chain\n")
+	b.WriteString(`call_chain: call ("." call)*` + "\n")
+	b.WriteString(`call: method "(" args? ")"` + "\n")
+	b.WriteString("method: " + strings.Join(alts, " | ") + "\n")
+	b.WriteString(`args: arg ("," arg)*` + "\n")
+	b.WriteString(`arg: NAME "=" value | value` + "\n")
+	b.WriteString(`value: NUMBER | STRING | BOOL | NULL | call | list` + "\n")
+	b.WriteString(`list: "[" (value ("," value)*)? "]"` + "\n")
+	return b.String(), nil
+}
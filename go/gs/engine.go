@@ -0,0 +1,1176 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VerbHandler is the reflection-bound form of a MethodHandler: a method
+// value from the DSL, already bound to its receiver.
+type VerbHandler func(args Args) error
+
+// GuardArgName is the reserved argument name the Engine checks on every
+// call before dispatching it: `draw(x=1, if=enabled)` only calls draw if
+// the "enabled" identifier resolves (via the SymbolTable) to a true
+// ValueBool. This gives DSL programs conditional execution without adding
+// if/else syntax to the grammar itself. The guard argument is stripped
+// before the remaining args reach the method handler.
+const GuardArgName = "if"
+
+// RepeatArgName is the reserved argument name the Engine checks on every
+// call before dispatching it: `blink(times=3)` calls blink three times in a
+// row, stopping early if an attempt errors. A missing "times" argument
+// behaves as times=1. The repeat argument is stripped before the remaining
+// args reach the method handler.
+const RepeatArgName = "times"
+
+// ArgValidator is an optional interface a DSL can implement to check
+// argument arity and type before a method handler is dispatched. method is
+// the DSL verb name (snake_case), not the Go method name.
+type ArgValidator interface {
+	ValidateArgs(method string, args Args) error
+}
+
+// ArgDefaulter is an optional interface a DSL can implement to supply
+// default values for a method's arguments. DefaultArgs returns the defaults
+// for method (snake_case); any of them missing from the call's actual args
+// are filled in before dispatch, so explicitly-passed args always win.
+type ArgDefaulter interface {
+	DefaultArgs(method string) Args
+}
+
+// ArgAliases is an optional interface a DSL can implement to tolerate
+// argument name variance from an LLM (e.g. "title" instead of "name").
+// ArgAliases returns, for method (snake_case), a map from each alias
+// source name to the canonical argument name it should be treated as; a
+// method with no aliases can omit it from the map. buildArgs rewrites a
+// call's argument names through this map before dispatch, so handlers
+// only ever see the canonical name. If a call supplies two different
+// source names that resolve to the same canonical name (including the
+// canonical name itself, passed alongside an alias for it), that is
+// reported as an error rather than one silently overwriting the other.
+type ArgAliases interface {
+	ArgAliases(method string) map[string]string
+}
+
+// ArgPolicyKind identifies which argument styles a method accepts.
+type ArgPolicyKind int
+
+const (
+	// ArgsAny accepts both positional and keyword arguments (the default).
+	ArgsAny ArgPolicyKind = iota
+	// ArgsKeywordOnly rejects calls with any positional (unnamed) argument.
+	ArgsKeywordOnly
+	// ArgsPositionalOnly rejects calls with any keyword (named) argument.
+	ArgsPositionalOnly
+)
+
+// ArgPolicyProvider is an optional interface a DSL can implement to
+// restrict a method (snake_case) to only keyword arguments, only
+// positional arguments, or both (ArgsAny, the default for methods a
+// DSL implementing this interface doesn't mention).
+type ArgPolicyProvider interface {
+	ArgPolicy(method string) ArgPolicyKind
+}
+
+func checkArgPolicy(call Call, policy ArgPolicyKind) error {
+	switch policy {
+	case ArgsKeywordOnly:
+		for _, a := range call.Args {
+			if a.Name == "" {
+				return fmt.Errorf("gs: %s: method only accepts keyword arguments", call.Name)
+			}
+		}
+	case ArgsPositionalOnly:
+		for _, a := range call.Args {
+			if a.Name != "" {
+				return fmt.Errorf("gs: %s: method only accepts positional arguments", call.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// MethodDescriber is an optional interface a DSL can implement to expose a
+// human-readable description of one of its methods (snake_case verb name),
+// for use when generating CFG tool definitions or other documentation.
+type MethodDescriber interface {
+	MethodDescription(method string) string
+}
+
+// MethodDescription returns the description name's DSL registers via
+// MethodDescriber, or "" if the DSL doesn't implement that interface or has
+// no description for name.
+func (e *Engine) MethodDescription(name string) string {
+	describer, ok := e.dsl.(MethodDescriber)
+	if !ok {
+		return ""
+	}
+	return describer.MethodDescription(name)
+}
+
+// BeforeHook runs immediately before a call is dispatched. Returning an
+// error aborts execution without calling the method handler.
+type BeforeHook func(call Call) error
+
+// AfterHook runs immediately after a call is dispatched, receiving the
+// error (if any) returned by the method handler.
+type AfterHook func(call Call, err error)
+
+// Engine orchestrates parsing and direct execution of DSL method calls.
+type Engine struct {
+	grammar       string
+	parser        Parser
+	verbsMu       sync.RWMutex
+	verbs         map[string]VerbHandler
+	dsl           interface{}
+	beforeHooks   []BeforeHook
+	afterHooks    []AfterHook
+	eventHooks    []EventHook
+	parseCache    sync.Map // source string -> *CallChain
+	allowList     map[string]bool
+	denyList      map[string]bool
+	symbols       *SymbolTable
+	collectErrs   bool
+	callTimeout   time.Duration
+	retryMax      int
+	retryBackoff  time.Duration
+	wildcard      WildcardHandler
+	resultVerbsMu sync.RWMutex
+	resultVerbs   map[string]ResultHandler
+	argsPool      sync.Pool
+	caseSensitive bool
+	coerceArgs    bool
+	actionVerbsMu sync.RWMutex
+	actionVerbs   map[string]ActionHandler
+	runtime       Runtime
+	contextVerbsMu           sync.RWMutex
+	contextVerbs             map[string]ContextHandler
+	activeContext            *Context
+	contextDefaults          bool
+	helpEnabled              bool
+	trackCurrent             bool
+	logger                   *slog.Logger
+	argBounds                map[string]map[string]argBoundsRange
+	grammarVersionConstraint string
+	argTransformer           ArgTransformer
+	strictArgs               bool
+	argSchemas               map[string][]ArgSpec
+}
+
+// argBoundsRange is the inclusive [min, max] range a numeric argument must
+// fall within, configured via Engine.SetArgBounds.
+type argBoundsRange struct {
+	min, max float64
+}
+
+// SetLogger configures l to receive debug-level structured log records for
+// every dispatched call: "method", "arg_count", and "duration". This is
+// zero-config observability for apps already using log/slog, distinct from
+// any tracer hook (see EventHook); a nil logger (the default) disables
+// logging with no overhead beyond a nil check.
+func (e *Engine) SetLogger(l *slog.Logger) {
+	e.logger = l
+}
+
+// SetRuntime configures the Runtime that ActionHandler methods' Actions are
+// dispatched to (see ActionHandler). It has no effect on VerbHandler or
+// ResultHandler methods, which already execute directly.
+func (e *Engine) SetRuntime(runtime Runtime) {
+	e.runtime = runtime
+}
+
+// SetCoerceArgs controls whether Args.GetString and Args.GetNumber fall back
+// to lenient type coercion (stringifying numbers/bools, parsing numeric
+// strings) instead of only accepting an exact Kind match. It is off by
+// default, so strict DSLs reject type mismatches; enable it for DSLs that
+// would rather tolerate an LLM sending count="3" or name=5 than fail the
+// call.
+func (e *Engine) SetCoerceArgs(coerce bool) {
+	e.coerceArgs = coerce
+}
+
+// ArgTransformer normalizes a single resolved argument value before
+// dispatch, e.g. trimming a string, clamping a number, or resolving a
+// relative path into an absolute one. See Engine.SetArgTransformer.
+type ArgTransformer func(method, arg string, v Value) (Value, error)
+
+// SetArgTransformer installs a single hook run on every argument of every
+// call, in buildArgs right after alias resolution (see ArgAliases) assigns
+// it its final name - so arg is always the canonical name a method handler
+// would see, not a source alias. It runs before the Engine's coercion flag
+// is attached and before SetArgBounds checks, so a transformer can reshape
+// a value (e.g. clamp gain to [0, 1]) before bounds validation sees it; a
+// transformer that needs to read the raw value across type boundaries can
+// still rely on Args.GetString/GetNumber coercion afterward, since that
+// runs lazily whenever a handler reads the (possibly transformed) value. A
+// transformer error aborts the call before the handler runs, wrapped with
+// the method and argument name. This is a single seam for cross-cutting
+// argument hygiene that would otherwise need duplicating in every handler;
+// a nil transformer (the default) leaves every argument untouched.
+func (e *Engine) SetArgTransformer(transform ArgTransformer) {
+	e.argTransformer = transform
+}
+
+// EngineOption configures optional Engine behavior at construction time, for
+// use with NewEngine.
+type EngineOption func(*Engine)
+
+// WithCaseSensitiveMethods disables the Engine's default case-insensitive
+// method matching, so e.g. "play" and "Play" dispatch to distinct verbs
+// instead of both folding onto the same lowercased registration. Case
+// insensitivity is the default since LLM-generated DSL code is inconsistent
+// about case; enabling this option can re-expose collisions between methods
+// that previously differed only by case, so it should only be used by DSLs
+// that genuinely need two verbs differing solely in case.
+func WithCaseSensitiveMethods() EngineOption {
+	return func(e *Engine) { e.caseSensitive = true }
+}
+
+// methodKey normalizes a verb name for use as a map key, folding case unless
+// the Engine was built with WithCaseSensitiveMethods.
+func (e *Engine) methodKey(name string) string {
+	if e.caseSensitive {
+		return name
+	}
+	return strings.ToLower(name)
+}
+
+var methodHandlerType = reflect.TypeOf((func(Args) error)(nil))
+
+// NewEngine builds an Engine for dsl using parser. If grammar is empty, the
+// Engine relies entirely on parser knowing how to read the grammar it was
+// built for. Method handlers are discovered from dsl via reflection: any
+// exported method matching func(Args) error is registered under its
+// snake_case name. By default method names are matched case-insensitively;
+// pass WithCaseSensitiveMethods to change that.
+func NewEngine(grammar string, dsl interface{}, parser Parser, opts ...EngineOption) (*Engine, error) {
+	if dsl == nil {
+		return nil, fmt.Errorf("gs: dsl must not be nil")
+	}
+	if parser == nil {
+		return nil, fmt.Errorf("gs: parser must not be nil")
+	}
+	e := &Engine{
+		grammar:      grammar,
+		parser:       parser,
+		dsl:          dsl,
+		verbs:        make(map[string]VerbHandler),
+		resultVerbs:  make(map[string]ResultHandler),
+		actionVerbs:  make(map[string]ActionHandler),
+		contextVerbs: make(map[string]ContextHandler),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.argsPool.New = func() interface{} { return make(Args) }
+	if err := e.discoverMethods(dsl); err != nil {
+		return nil, err
+	}
+	if err := e.discoverResultMethods(dsl); err != nil {
+		return nil, err
+	}
+	if err := e.discoverActionMethods(dsl); err != nil {
+		return nil, err
+	}
+	if err := e.discoverContextMethods(dsl); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// discoverMethods registers every method of dsl matching func(Args) error.
+// It fails if two methods (typically promoted from different embedded
+// mixins) register the same snake_case name, rather than letting the later
+// one silently shadow the earlier one.
+func (e *Engine) discoverMethods(dsl interface{}) error {
+	v := reflect.ValueOf(dsl)
+	t := v.Type()
+	e.verbsMu.Lock()
+	defer e.verbsMu.Unlock()
+	claimedBy := make(map[string]string, t.NumMethod())
+	var collisions []string
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		methodValue := v.Method(i)
+		if methodValue.Type() != methodHandlerType {
+			continue
+		}
+		key := e.methodKey(toSnakeCase(m.Name))
+		if first, ok := claimedBy[key]; ok {
+			collisions = append(collisions, fmt.Sprintf("%q (from %s and %s)", key, first, m.Name))
+			continue
+		}
+		claimedBy[key] = m.Name
+		handler := methodValue.Interface().(func(Args) error)
+		e.verbs[key] = VerbHandler(handler)
+	}
+	collisions = append(collisions, e.ambiguousEmbeddedCollisions(dsl, methodHandlerType)...)
+	return collisionError(collisions)
+}
+
+// ambiguousEmbeddedCollisions finds method names matching handlerType that
+// are defined directly on two or more of dsl's embedded (anonymous)
+// fields, e.g. two mixins that both define a same-named verb. Go's
+// reflection treats such a name as ambiguous at that promotion depth and
+// excludes it from t.NumMethod() entirely - neither colliding method ever
+// reaches t.Method(i) - so discoverMethods and its ResultHandler/
+// ActionHandler/ContextHandler counterparts can't see the collision by
+// scanning the promoted method set alone; this walks dsl's own embedded
+// fields directly instead. It catches the common case of two mixins
+// embedded directly in dsl, though it doesn't replicate Go's full
+// multi-level embedding/promotion algorithm for deeper hierarchies.
+func (e *Engine) ambiguousEmbeddedCollisions(dsl interface{}, handlerType reflect.Type) []string {
+	v := reflect.ValueOf(dsl)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+	definedBy := make(map[string][]string)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		fv := v.Field(i)
+		ft := fv.Type()
+		for j := 0; j < ft.NumMethod(); j++ {
+			if fv.Method(j).Type() != handlerType {
+				continue
+			}
+			key := e.methodKey(toSnakeCase(ft.Method(j).Name))
+			definedBy[key] = append(definedBy[key], field.Name)
+		}
+	}
+	var names []string
+	for name, definers := range definedBy {
+		if len(definers) > 1 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	collisions := make([]string, 0, len(names))
+	for _, name := range names {
+		collisions = append(collisions, fmt.Sprintf("%q (ambiguously promoted from embedded %s)", name, strings.Join(definedBy[name], " and ")))
+	}
+	return collisions
+}
+
+// collisionError turns the collisions discoverMethods (or its ResultHandler
+// counterpart, discoverResultMethods) found into a single descriptive
+// error, or nil if there were none.
+func collisionError(collisions []string) error {
+	if len(collisions) == 0 {
+		return nil
+	}
+	sort.Strings(collisions)
+	return fmt.Errorf("gs: colliding method names %s; disambiguate with Engine.RegisterMethod or Engine.RegisterResultMethod", strings.Join(collisions, ", "))
+}
+
+// Grammar returns the grammar string the Engine was constructed with.
+func (e *Engine) Grammar() string {
+	return e.grammar
+}
+
+// WildcardHandler handles any call for which no verb is registered. method
+// is the DSL verb name that was actually called.
+type WildcardHandler func(method string, args Args) error
+
+// SetWildcardHandler registers handler as the catch-all for calls to
+// methods with no registered verb, so a DSL can accept arbitrary verbs
+// (e.g. to forward them to a generic backend) instead of failing with
+// UnknownMethodError. A nil handler removes the catch-all.
+func (e *Engine) SetWildcardHandler(handler WildcardHandler) {
+	e.wildcard = handler
+}
+
+// RegisterMethod registers handler under name, overwriting any handler
+// already registered (whether discovered via reflection or registered
+// manually) under that name. This lets callers expose verbs backed by
+// closures, free functions, or methods that don't match the reflection
+// signature used by NewEngine. It is safe to call concurrently with
+// dispatch, including while other goroutines are executing DSL programs.
+func (e *Engine) RegisterMethod(name string, handler MethodHandler) {
+	e.verbsMu.Lock()
+	defer e.verbsMu.Unlock()
+	e.verbs[e.methodKey(name)] = VerbHandler(handler)
+}
+
+// Alias registers extra so that it dispatches to the same handler already
+// registered under target, letting a single Go method answer to more than
+// one DSL verb name (e.g. both "add" and "add_clip").
+func (e *Engine) Alias(extra, target string) error {
+	e.verbsMu.Lock()
+	defer e.verbsMu.Unlock()
+	handler, ok := e.verbs[e.methodKey(target)]
+	if !ok {
+		return fmt.Errorf("gs: cannot alias %q: %q is not a registered method", extra, target)
+	}
+	e.verbs[e.methodKey(extra)] = handler
+	return nil
+}
+
+// Methods returns the names of every method currently registered with the
+// Engine (whether discovered via reflection, registered manually, or
+// aliased), in sorted order.
+func (e *Engine) Methods() []string {
+	e.verbsMu.RLock()
+	defer e.verbsMu.RUnlock()
+	names := make([]string, 0, len(e.verbs))
+	for name := range e.verbs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// HasMethod reports whether name is currently registered.
+func (e *Engine) HasMethod(name string) bool {
+	_, ok := e.verb(name)
+	return ok
+}
+
+// verb returns the handler registered under name, if any. It is safe to
+// call concurrently with RegisterMethod and Alias.
+func (e *Engine) verb(name string) (VerbHandler, bool) {
+	e.verbsMu.RLock()
+	defer e.verbsMu.RUnlock()
+	handler, ok := e.verbs[e.methodKey(name)]
+	return handler, ok
+}
+
+// verbSnapshot returns a copy of the registered verb names, suitable for
+// handing to helpers like suggestMethod that must not race with concurrent
+// registration.
+func (e *Engine) verbSnapshot() map[string]VerbHandler {
+	e.verbsMu.RLock()
+	defer e.verbsMu.RUnlock()
+	snapshot := make(map[string]VerbHandler, len(e.verbs))
+	for name, handler := range e.verbs {
+		snapshot[name] = handler
+	}
+	return snapshot
+}
+
+// Allow restricts the Engine to only dispatch the given method names. Once
+// set, any call outside the allow list is rejected, even if Deny would
+// otherwise permit it.
+func (e *Engine) Allow(names ...string) {
+	e.allowList = make(map[string]bool, len(names))
+	for _, name := range names {
+		e.allowList[e.methodKey(name)] = true
+	}
+}
+
+// Deny blocks the given method names from being dispatched, even if they
+// are registered and (when no allow list is set) otherwise permitted.
+func (e *Engine) Deny(names ...string) {
+	if e.denyList == nil {
+		e.denyList = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		e.denyList[e.methodKey(name)] = true
+	}
+}
+
+// SetArgBounds restricts a numeric argument of method (snake_case) to the
+// inclusive range [min, max]. A call whose value for arg falls outside that
+// range is rejected with a *BoundsError before the method handler runs,
+// instead of reaching hardware or an external API with an out-of-range
+// value (e.g. an LLM emitting gain=9999). Bounds are checked using the same
+// coercion rules as Args.GetNumber, so they apply to a numeric string like
+// gain="9999" exactly as they would to gain=9999 when SetCoerceArgs(true) is
+// in effect. An argument with no bounds configured is unrestricted.
+func (e *Engine) SetArgBounds(method, arg string, min, max float64) {
+	if e.argBounds == nil {
+		e.argBounds = make(map[string]map[string]argBoundsRange)
+	}
+	key := e.methodKey(method)
+	if e.argBounds[key] == nil {
+		e.argBounds[key] = make(map[string]argBoundsRange)
+	}
+	e.argBounds[key][arg] = argBoundsRange{min: min, max: max}
+}
+
+// checkArgBounds reports a *BoundsError if any argument of call has a bound
+// configured via SetArgBounds and its (possibly coerced) numeric value falls
+// outside it. An argument that is absent, or present but not numeric and not
+// coercible, is not checked here - ArgValidator is the place to require it.
+func (e *Engine) checkArgBounds(call Call, args Args) error {
+	bounds, ok := e.argBounds[e.methodKey(call.Name)]
+	if !ok {
+		return nil
+	}
+	for name, b := range bounds {
+		v, ok := args.GetNumber(name)
+		if !ok {
+			continue
+		}
+		if v < b.min || v > b.max {
+			return &BoundsError{Method: call.Name, Arg: name, Value: v, Min: b.min, Max: b.max}
+		}
+	}
+	return nil
+}
+
+// ArgSpec declares one argument a method accepts, for Engine.SetArgSchema.
+// Kind is only checked when a call actually supplies that argument; Required
+// additionally rejects a call that omits it.
+type ArgSpec struct {
+	Name     string
+	Kind     ValueKind
+	Required bool
+}
+
+// SetArgSchema declares the full set of arguments method (snake_case)
+// accepts, for use by Engine.SetStrictArgs. A method with no schema
+// registered is never checked, strict mode or not - schemas are opt-in per
+// method, so a DSL can tighten the methods an LLM gets wrong most often
+// without having to describe every method up front.
+func (e *Engine) SetArgSchema(method string, specs ...ArgSpec) {
+	if e.argSchemas == nil {
+		e.argSchemas = make(map[string][]ArgSpec)
+	}
+	e.argSchemas[e.methodKey(method)] = specs
+}
+
+// SetStrictArgs controls whether calls to a method with a schema registered
+// via SetArgSchema are checked against it: an argument name the schema
+// doesn't declare, a required argument the call omits, or a declared
+// argument whose value's Kind doesn't match are all rejected with an
+// *ArgSchemaError before the method handler runs, instead of the unknown
+// argument being silently dropped and the missing one read as zero. It is
+// off by default, which preserves today's lenient behavior exactly,
+// including for methods that do have a schema registered.
+func (e *Engine) SetStrictArgs(strict bool) {
+	e.strictArgs = strict
+}
+
+// checkArgSchema reports an *ArgSchemaError if call.Name has a schema
+// registered via SetArgSchema and args violates it. A method with no schema
+// registered is unrestricted, the same way checkArgBounds leaves an argument
+// with no configured bounds unrestricted.
+func (e *Engine) checkArgSchema(call Call, args Args) error {
+	specs, ok := e.argSchemas[e.methodKey(call.Name)]
+	if !ok {
+		return nil
+	}
+	known := make(map[string]ArgSpec, len(specs))
+	for _, spec := range specs {
+		known[spec.Name] = spec
+	}
+	for name := range args {
+		if name == coerceArgKey || name == currentArgKey {
+			continue
+		}
+		if _, declared := known[name]; !declared {
+			return &ArgSchemaError{Method: call.Name, Arg: name, Reason: "is not a recognized argument"}
+		}
+	}
+	for _, spec := range specs {
+		v, present := args[spec.Name]
+		if !present {
+			if spec.Required {
+				return &ArgSchemaError{Method: call.Name, Arg: spec.Name, Reason: "is required"}
+			}
+			continue
+		}
+		if v.Kind != spec.Kind {
+			return &ArgSchemaError{Method: call.Name, Arg: spec.Name, Reason: fmt.Sprintf("must be %s, got %s", argKindName(spec.Kind), argKindName(v.Kind))}
+		}
+	}
+	return nil
+}
+
+// argKindName renders a ValueKind the way ArgSchemaError messages do.
+func argKindName(kind ValueKind) string {
+	switch kind {
+	case ValueNumber:
+		return "a number"
+	case ValueString:
+		return "a string"
+	case ValueBool:
+		return "a bool"
+	default:
+		return "a value"
+	}
+}
+
+// TransientError is an optional interface an error returned by a method
+// handler can implement to mark itself safe to retry, e.g. a network call
+// that failed because of a momentary outage.
+type TransientError interface {
+	error
+	Transient() bool
+}
+
+// SetRetryPolicy makes the Engine retry a failing call up to maxAttempts
+// times in total (including the first attempt) when its error implements
+// TransientError and reports Transient() true, waiting backoff between
+// attempts and doubling the wait after each retry. maxAttempts <= 1
+// disables retrying, which is the default.
+func (e *Engine) SetRetryPolicy(maxAttempts int, backoff time.Duration) {
+	e.retryMax = maxAttempts
+	e.retryBackoff = backoff
+}
+
+// SetCallTimeout bounds how long a single method handler is allowed to run.
+// If a handler does not return within d, the call fails with a timeout
+// error and execution proceeds (or stops, per CollectErrors) as if the
+// handler had returned that error; the handler's goroutine is left to
+// finish in the background since MethodHandler has no way to cancel it. A
+// zero duration (the default) disables the timeout.
+func (e *Engine) SetCallTimeout(d time.Duration) {
+	e.callTimeout = d
+}
+
+// RequireGrammarVersion makes ExecuteWithChain (and Execute, which calls it)
+// reject a statement whose leading version("1.2") pragma doesn't satisfy
+// constraint (e.g. ">=1.0", "1.2", "^1.2"; see matchesVersionConstraint),
+// failing with a *GrammarVersionError instead of running its calls. This
+// guards against old cached LLM output generated against a grammar that has
+// since changed semantics. A statement with no version pragma is never
+// rejected by this check, since it declares no version to compare; an empty
+// constraint (the default) disables the check entirely.
+func (e *Engine) RequireGrammarVersion(constraint string) {
+	e.grammarVersionConstraint = constraint
+}
+
+// CollectErrors controls whether executing a chain stops at the first
+// failing call (the default) or keeps dispatching every remaining call and
+// returns all of the errors joined together via errors.Join.
+func (e *Engine) CollectErrors(collect bool) {
+	e.collectErrs = collect
+}
+
+func (e *Engine) checkAllowed(name string) error {
+	key := e.methodKey(name)
+	if e.allowList != nil && !e.allowList[key] {
+		return &DeniedMethodError{Method: name, Reason: "is not in the allow list"}
+	}
+	if e.denyList != nil && e.denyList[key] {
+		return &DeniedMethodError{Method: name, Reason: "is denied"}
+	}
+	return nil
+}
+
+// Before registers a hook that runs before every call is dispatched.
+func (e *Engine) Before(hook BeforeHook) {
+	e.beforeHooks = append(e.beforeHooks, hook)
+}
+
+// After registers a hook that runs after every call is dispatched.
+func (e *Engine) After(hook AfterHook) {
+	e.afterHooks = append(e.afterHooks, hook)
+}
+
+// parse parses code, caching the result keyed by the source string so that
+// repeatedly executing the same program (a common pattern when an LLM keeps
+// emitting the same DSL snippet) skips re-parsing.
+func (e *Engine) parse(code string) (*CallChain, error) {
+	if cached, ok := e.parseCache.Load(code); ok {
+		return cached.(*CallChain), nil
+	}
+	start := time.Now()
+	chain, err := e.parser.Parse(code)
+	if e.logger != nil {
+		e.logger.Debug("gs: parse", "len", len(code), "duration", time.Since(start), "error", err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	e.parseCache.Store(code, chain)
+	return chain, nil
+}
+
+// Validate parses code and checks that every call across all of its
+// statements resolves to a registered, permitted method, without invoking
+// any handler. It is useful for checking LLM-generated DSL programs before
+// execution. code may contain multiple independent statements separated by
+// newlines or semicolons.
+func (e *Engine) Validate(code string) error {
+	for _, statement := range splitStatements(code) {
+		chain, err := e.parse(statement)
+		if err != nil {
+			return fmt.Errorf("gs: parse error: %w", err)
+		}
+		for _, call := range chain.Calls {
+			if _, ok := e.verb(call.Name); !ok {
+				return &UnknownMethodError{Method: call.Name, Suggestion: suggestMethod(call.Name, e.verbSnapshot())}
+			}
+			if err := e.checkAllowed(call.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Compile parses code (which may contain multiple statements separated by
+// newlines or semicolons) and converts each call into an Action without
+// invoking any method handler. It lets callers collect, inspect, or replay
+// the work a program would do before (or instead of) executing it directly.
+func (e *Engine) Compile(code string) ([]Action, error) {
+	var actions []Action
+	for _, statement := range splitStatements(code) {
+		chain, err := e.parse(statement)
+		if err != nil {
+			return nil, fmt.Errorf("gs: parse error: %w", err)
+		}
+		for _, call := range chain.Calls {
+			payload := make(map[string]interface{}, len(call.Args))
+			for _, a := range call.Args {
+				payload[a.Name] = a.Value
+			}
+			actions = append(actions, Action{Kind: call.Name, Payload: payload})
+		}
+	}
+	return actions, nil
+}
+
+// Execute parses code and calls the resolved method handlers in order.
+func (e *Engine) Execute(ctx context.Context, code string) error {
+	_, err := e.ExecuteWithChain(ctx, code)
+	return err
+}
+
+// ExecuteWithChain behaves like Execute but also returns every parsed
+// CallChain, letting callers inspect exactly what was run. code may contain
+// multiple independent statements separated by newlines or semicolons; each
+// is parsed and executed in order, and the chains already run are returned
+// even if a later statement fails.
+func (e *Engine) ExecuteWithChain(ctx context.Context, code string) ([]*CallChain, error) {
+	var chains []*CallChain
+	for _, statement := range splitStatements(code) {
+		chain, err := e.parse(statement)
+		if err != nil {
+			return chains, fmt.Errorf("gs: parse error: %w", err)
+		}
+		chains = append(chains, chain)
+		if e.grammarVersionConstraint != "" && chain.Version != "" {
+			ok, err := matchesVersionConstraint(chain.Version, e.grammarVersionConstraint)
+			if err != nil {
+				return chains, fmt.Errorf("gs: grammar version check: %w", err)
+			}
+			if !ok {
+				return chains, &GrammarVersionError{Declared: chain.Version, Constraint: e.grammarVersionConstraint}
+			}
+		}
+		if err := e.executeChain(ctx, chain); err != nil {
+			return chains, err
+		}
+	}
+	return chains, nil
+}
+
+// ExecuteReader reads all of r and executes it as a (possibly
+// multi-statement) DSL program, for callers driving Execute off a file or
+// network stream rather than an in-memory string.
+func (e *Engine) ExecuteReader(ctx context.Context, r io.Reader) error {
+	code, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("gs: reading DSL source: %w", err)
+	}
+	return e.Execute(ctx, string(code))
+}
+
+func (e *Engine) executeChain(ctx context.Context, chain *CallChain) error {
+	var errs []error
+	for _, call := range chain.Calls {
+		if err := e.executeCall(ctx, call); err != nil {
+			if !e.collectErrs {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (e *Engine) executeCall(ctx context.Context, call Call) error {
+	_, err := e.executeCallWithResult(ctx, call)
+	return err
+}
+
+// buildArgs resolves call's arguments into an Args map drawn from e.argsPool
+// (see putArgs), assigning each positional argument the reserved key
+// PositionalArgName(i) for its index i among the call's positional
+// arguments. A spread argument (*list) must resolve to a ValueList; its
+// elements are expanded into their own positional slots rather than being
+// passed as a single list argument, e.g. `point(*coords)` with
+// coords=[1, 2] dispatches exactly like `point(1, 2)`. A list literal's
+// elements are evaluated via evalListCalls, so any of them written as a
+// nested call (e.g. `tracks([track(name="A"), track(name="B")])`) reaches
+// the handler as that call's returned Value rather than its unevaluated
+// Call AST.
+func (e *Engine) buildArgs(ctx context.Context, call Call) (Args, error) {
+	args := e.argsPool.Get().(Args)
+	var aliases map[string]string
+	if provider, ok := e.dsl.(ArgAliases); ok {
+		aliases = provider.ArgAliases(call.Name)
+	}
+	var targetSource map[string]string
+	pos := 0
+	for _, a := range call.Args {
+		if a.Spread {
+			value := e.resolveValue(a.Value)
+			if value.Kind != ValueList {
+				e.putArgs(args)
+				return nil, fmt.Errorf("gs: %s: cannot spread a non-list argument", call.Name)
+			}
+			value, err := e.evalListCalls(ctx, value, 0)
+			if err != nil {
+				e.putArgs(args)
+				return nil, fmt.Errorf("gs: %s: %w", call.Name, err)
+			}
+			for _, elem := range value.List {
+				name := PositionalArgName(pos)
+				pos++
+				transformed, err := e.transformArg(call.Name, name, elem)
+				if err != nil {
+					e.putArgs(args)
+					return nil, err
+				}
+				args[name] = transformed
+			}
+			continue
+		}
+		orig := a.Name
+		name := orig
+		if name == "" {
+			name = PositionalArgName(pos)
+			pos++
+		} else if target, ok := aliases[name]; ok {
+			name = target
+		}
+		if orig != "" {
+			if targetSource == nil {
+				targetSource = make(map[string]string)
+			}
+			if prior, used := targetSource[name]; used && prior != orig {
+				e.putArgs(args)
+				return nil, fmt.Errorf("gs: %s: arguments %q and %q both resolve to %q", call.Name, prior, orig, name)
+			}
+			targetSource[name] = orig
+		}
+		resolved, err := e.evalListCalls(ctx, e.resolveValue(a.Value), 0)
+		if err != nil {
+			e.putArgs(args)
+			return nil, fmt.Errorf("gs: %s: argument %q: %w", call.Name, name, err)
+		}
+		transformed, err := e.transformArg(call.Name, name, resolved)
+		if err != nil {
+			e.putArgs(args)
+			return nil, err
+		}
+		args[name] = transformed
+	}
+	if e.coerceArgs {
+		args[coerceArgKey] = NewBoolValue(true)
+	}
+	e.applyCurrentObject(args)
+	return args, nil
+}
+
+// maxNestedCallDepth bounds how deeply a list literal's elements may recurse
+// through nested lists while evalListCalls evaluates their calls (e.g. a
+// list of lists of calls), mirroring maxParseDepth's guard against
+// pathological nesting but at argument-evaluation time rather than parse
+// time.
+const maxNestedCallDepth = 200
+
+// evalListCalls evaluates every ValueCall element of v, recursing into
+// nested ValueLists, by dispatching each call via executeCallWithResult and
+// replacing it with its returned Value. This lets a list literal like
+// `tracks([track(name="A"), track(name="B")])` pass the outer handler the
+// two inner calls' actual results instead of their unevaluated Call ASTs.
+// v is returned unchanged if it isn't a ValueList; a list element that
+// isn't a ValueCall or ValueList is also left as-is. Errors are wrapped
+// with the failing element's index so callers can report which one failed.
+func (e *Engine) evalListCalls(ctx context.Context, v Value, depth int) (Value, error) {
+	if v.Kind != ValueList {
+		return v, nil
+	}
+	if depth > maxNestedCallDepth {
+		return Value{}, fmt.Errorf("gs: exceeded maximum nested call depth (%d) evaluating list", maxNestedCallDepth)
+	}
+	elems := make([]Value, len(v.List))
+	for i, elem := range v.List {
+		switch elem.Kind {
+		case ValueCall:
+			if elem.Call == nil {
+				elems[i] = elem
+				continue
+			}
+			result, err := e.executeCallWithResult(ctx, *elem.Call)
+			if err != nil {
+				return Value{}, fmt.Errorf("list element %d (%s): %w", i, elem.Call.Name, err)
+			}
+			elems[i] = result
+		case ValueList:
+			nested, err := e.evalListCalls(ctx, elem, depth+1)
+			if err != nil {
+				return Value{}, fmt.Errorf("list element %d: %w", i, err)
+			}
+			elems[i] = nested
+		default:
+			elems[i] = elem
+		}
+	}
+	return Value{Kind: ValueList, List: elems}, nil
+}
+
+// transformArg runs e.argTransformer (see SetArgTransformer) on v if one is
+// configured, returning v unchanged otherwise.
+func (e *Engine) transformArg(method, arg string, v Value) (Value, error) {
+	if e.argTransformer == nil {
+		return v, nil
+	}
+	transformed, err := e.argTransformer(method, arg, v)
+	if err != nil {
+		return Value{}, fmt.Errorf("gs: %s: arg transform for %q: %w", method, arg, err)
+	}
+	return transformed, nil
+}
+
+// executeCallWithResult runs call exactly like executeCall, additionally
+// returning the Value a ResultHandler produced for it (see result.go). If
+// call.Name resolves to a plain VerbHandler instead, the returned Value is
+// the zero Value, since there is nothing to report.
+func (e *Engine) executeCallWithResult(ctx context.Context, call Call) (Value, error) {
+	for _, hook := range e.beforeHooks {
+		if err := hook(call); err != nil {
+			return Value{}, fmt.Errorf("gs: before hook for %s: %w", call.Name, err)
+		}
+	}
+	resultHandler, hasResult := e.resultVerb(call.Name)
+	actionHandler, hasAction := e.actionVerb(call.Name)
+	contextHandler, hasContext := e.contextVerb(call.Name)
+	handler, ok := e.verb(call.Name)
+	if !ok && !hasResult && !hasAction && !hasContext && e.helpEnabled && e.methodKey(call.Name) == "help" {
+		resultHandler, hasResult = e.builtinHelp, true
+	}
+	if !ok && !hasResult && !hasAction && !hasContext {
+		if e.wildcard == nil {
+			err := &UnknownMethodError{Method: call.Name, Suggestion: suggestMethod(call.Name, e.verbSnapshot())}
+			e.runAfterHooks(call, err)
+			return Value{}, err
+		}
+		handler = func(args Args) error { return e.wildcard(call.Name, args) }
+	}
+	if err := e.checkAllowed(call.Name); err != nil {
+		e.runAfterHooks(call, err)
+		return Value{}, err
+	}
+	if policyProvider, ok := e.dsl.(ArgPolicyProvider); ok {
+		if err := checkArgPolicy(call, policyProvider.ArgPolicy(call.Name)); err != nil {
+			e.runAfterHooks(call, err)
+			return Value{}, err
+		}
+	}
+	args, err := e.buildArgs(ctx, call)
+	if err != nil {
+		e.runAfterHooks(call, err)
+		return Value{}, err
+	}
+	if guard, guarded := args[GuardArgName]; guarded {
+		delete(args, GuardArgName)
+		if guard.Kind != ValueBool {
+			err := fmt.Errorf("gs: %s: %q argument must be a bool", call.Name, GuardArgName)
+			e.runAfterHooks(call, err)
+			e.putArgs(args)
+			return Value{}, err
+		}
+		if !guard.Bool {
+			e.putArgs(args)
+			return Value{}, nil
+		}
+	}
+	repeat := 1
+	if times, repeated := args[RepeatArgName]; repeated {
+		delete(args, RepeatArgName)
+		if times.Kind != ValueNumber || times.Num < 0 {
+			err := fmt.Errorf("gs: %s: %q argument must be a non-negative number", call.Name, RepeatArgName)
+			e.runAfterHooks(call, err)
+			e.putArgs(args)
+			return Value{}, err
+		}
+		repeat = int(times.Num)
+	}
+	if defaulter, ok := e.dsl.(ArgDefaulter); ok {
+		for name, value := range defaulter.DefaultArgs(call.Name) {
+			if _, set := args[name]; !set {
+				args[name] = value
+			}
+		}
+	}
+	if validator, ok := e.dsl.(ArgValidator); ok {
+		if err := validator.ValidateArgs(call.Name, args); err != nil {
+			wrapped := &HandlerError{Method: call.Name, Err: err}
+			e.runAfterHooks(call, wrapped)
+			e.putArgs(args)
+			return Value{}, wrapped
+		}
+	}
+	if err := e.checkArgBounds(call, args); err != nil {
+		e.runAfterHooks(call, err)
+		e.putArgs(args)
+		return Value{}, err
+	}
+	if e.strictArgs {
+		if err := e.checkArgSchema(call, args); err != nil {
+			e.runAfterHooks(call, err)
+			e.putArgs(args)
+			return Value{}, err
+		}
+	}
+	var result Value
+	dispatch := handler
+	switch {
+	case hasResult:
+		dispatch = func(args Args) error {
+			v, err := resultHandler(args)
+			result = v
+			return err
+		}
+	case !ok && hasAction:
+		// ActionHandler and ContextHandler are lower-priority dispatch
+		// styles: they only apply when the call name resolved to neither a
+		// VerbHandler nor a ResultHandler (which, for reflection-discovered
+		// methods, is always the case, since a single Go method can only
+		// match one of the four handler signatures; the ambiguity can only
+		// arise from manual Register* calls under the same name).
+		dispatch = func(args Args) error {
+			return e.dispatchActions(ctx, call.Name, actionHandler, args)
+		}
+	case !ok && hasContext:
+		e.applyContextDefaults(args)
+		dispatch = func(args Args) error {
+			return contextHandler(e.activeContext, args)
+		}
+	}
+	e.emit(Event{Kind: EventCallStart, Call: call, At: time.Now()})
+	argCount := len(args)
+	dispatchStart := time.Now()
+	var callErr error
+	var leaked bool
+	for i := 0; i < repeat; i++ {
+		var attemptLeaked bool
+		if callErr, attemptLeaked = e.invokeWithRetry(ctx, call.Name, dispatch, args); attemptLeaked {
+			leaked = true
+		}
+		if callErr != nil {
+			break
+		}
+	}
+	if e.logger != nil {
+		e.logger.Debug("gs: dispatch", "method", call.Name, "arg_count", argCount, "duration", time.Since(dispatchStart))
+	}
+	if !leaked {
+		e.putArgs(args)
+	}
+	e.emit(Event{Kind: EventCallEnd, Call: call, Err: callErr, At: time.Now()})
+	e.runAfterHooks(call, callErr)
+	if callErr != nil {
+		return Value{}, &HandlerError{Method: call.Name, Err: callErr}
+	}
+	if hasResult {
+		e.recordCurrent(result)
+	}
+	return result, nil
+}
+
+// putArgs clears args and returns it to e.argsPool so a later call can reuse
+// its backing map instead of allocating a new one. Method handlers must not
+// retain an Args map past the call they were given it for: the Engine may
+// hand that same map (cleared and repopulated) to a completely unrelated
+// call once this one returns.
+func (e *Engine) putArgs(args Args) {
+	for k := range args {
+		delete(args, k)
+	}
+	e.argsPool.Put(args)
+}
+
+// invokeWithRetry calls handler with args via invoke, retrying per the
+// Engine's retry policy (see SetRetryPolicy) when the error is transient.
+// leaked reports whether any attempt timed out and left its handler
+// goroutine running in the background (see invoke); callers must not reuse
+// args (e.g. return it to argsPool) when leaked is true, since that
+// goroutine may still be reading from it.
+func (e *Engine) invokeWithRetry(ctx context.Context, method string, handler VerbHandler, args Args) (err error, leaked bool) {
+	attempts := e.retryMax
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := e.retryBackoff
+	for attempt := 0; attempt < attempts; attempt++ {
+		var attemptLeaked bool
+		err, attemptLeaked = e.invoke(ctx, method, handler, args)
+		leaked = leaked || attemptLeaked
+		if err == nil {
+			return nil, leaked
+		}
+		var transient TransientError
+		if !errors.As(err, &transient) || !transient.Transient() || attempt == attempts-1 {
+			return err, leaked
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return err, leaked
+}
+
+// invoke calls handler with args, enforcing e.callTimeout if one is set.
+// leaked reports whether the call timed out before handler returned, in
+// which case handler's goroutine is left running in the background since
+// VerbHandler has no way to cancel it, and may still be reading from args.
+func (e *Engine) invoke(ctx context.Context, method string, handler VerbHandler, args Args) (err error, leaked bool) {
+	if e.callTimeout <= 0 {
+		return handler(args), false
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- handler(args)
+	}()
+	select {
+	case err := <-done:
+		return err, false
+	case <-time.After(e.callTimeout):
+		return fmt.Errorf("gs: %s: timed out after %s", method, e.callTimeout), true
+	}
+}
+
+func (e *Engine) runAfterHooks(call Call, err error) {
+	for _, hook := range e.afterHooks {
+		hook(call, err)
+	}
+}
+
+// toSnakeCase converts an exported Go method name (e.g. AddClip) to its DSL
+// verb name (e.g. add_clip).
+func toSnakeCase(s string) string {
+	out := make([]rune, 0, len(s)+4)
+	for i, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				out = append(out, '_')
+			}
+			out = append(out, r-'A'+'a')
+			continue
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
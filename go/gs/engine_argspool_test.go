@@ -0,0 +1,95 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+type argsPoolDSL struct{}
+
+func (argsPoolDSL) Guarded(args Args) error  { return nil }
+func (argsPoolDSL) Repeated(args Args) error { return nil }
+func (argsPoolDSL) Bounded(args Args) error  { return nil }
+
+// alwaysInvalidDSL implements ArgValidator and rejects every call, to
+// exercise executeCallWithResult's ArgValidator early-return path.
+type alwaysInvalidDSL struct{}
+
+func (alwaysInvalidDSL) Validated(args Args) error { return nil }
+
+func (alwaysInvalidDSL) ValidateArgs(method string, args Args) error {
+	return fmt.Errorf("gs: %s always fails validation", method)
+}
+
+// countingPoolEngine builds an Engine over dsl whose argsPool.New is wrapped
+// to count every allocation it performs, so a test can assert that a call
+// failing one of executeCallWithResult's early checks still recycles its
+// Args map into the pool instead of leaking it.
+func countingPoolEngine(t *testing.T, dsl interface{}) (*Engine, *int64) {
+	t.Helper()
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	var allocs int64
+	e.argsPool.New = func() interface{} {
+		atomic.AddInt64(&allocs, 1)
+		return make(Args)
+	}
+	return e, &allocs
+}
+
+func TestExecuteCallRecyclesArgsOnGuardFalse(t *testing.T) {
+	e, allocs := countingPoolEngine(t, argsPoolDSL{})
+	for i := 0; i < 50; i++ {
+		if err := e.Execute(context.Background(), `guarded(if=false)`); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	}
+	if got := atomic.LoadInt64(allocs); got > 1 {
+		t.Errorf("argsPool.New called %d times over 50 guard-false calls, want at most 1 (args should be recycled, not leaked)", got)
+	}
+}
+
+func TestExecuteCallRecyclesArgsOnGuardTypeError(t *testing.T) {
+	e, allocs := countingPoolEngine(t, argsPoolDSL{})
+	for i := 0; i < 50; i++ {
+		_ = e.Execute(context.Background(), `guarded(if="not a bool")`)
+	}
+	if got := atomic.LoadInt64(allocs); got > 1 {
+		t.Errorf("argsPool.New called %d times over 50 failing calls, want at most 1 (args should be recycled, not leaked)", got)
+	}
+}
+
+func TestExecuteCallRecyclesArgsOnTimesTypeError(t *testing.T) {
+	e, allocs := countingPoolEngine(t, argsPoolDSL{})
+	for i := 0; i < 50; i++ {
+		_ = e.Execute(context.Background(), `repeated(times="lots")`)
+	}
+	if got := atomic.LoadInt64(allocs); got > 1 {
+		t.Errorf("argsPool.New called %d times over 50 failing calls, want at most 1 (args should be recycled, not leaked)", got)
+	}
+}
+
+func TestExecuteCallRecyclesArgsOnValidatorError(t *testing.T) {
+	e, allocs := countingPoolEngine(t, alwaysInvalidDSL{})
+	for i := 0; i < 50; i++ {
+		_ = e.Execute(context.Background(), `validated()`)
+	}
+	if got := atomic.LoadInt64(allocs); got > 1 {
+		t.Errorf("argsPool.New called %d times over 50 failing calls, want at most 1 (args should be recycled, not leaked)", got)
+	}
+}
+
+func TestExecuteCallRecyclesArgsOnBoundsError(t *testing.T) {
+	e, allocs := countingPoolEngine(t, argsPoolDSL{})
+	e.SetArgBounds("bounded", "gain", 0, 10)
+	for i := 0; i < 50; i++ {
+		_ = e.Execute(context.Background(), `bounded(gain=9999)`)
+	}
+	if got := atomic.LoadInt64(allocs); got > 1 {
+		t.Errorf("argsPool.New called %d times over 50 failing calls, want at most 1 (args should be recycled, not leaked)", got)
+	}
+}
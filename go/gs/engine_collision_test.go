@@ -0,0 +1,53 @@
+package gs
+
+import (
+	"strings"
+	"testing"
+)
+
+// functionalMixin and controlFlowMixin stand in for two independently
+// authored DSL mixins that happen to both define a "Foo" verb method - the
+// motivating case for ambiguousEmbeddedCollisions: Go's reflection excludes
+// a same-named, same-depth promoted method from the method set entirely,
+// so NewEngine must detect the collision by walking the embedded fields
+// directly rather than relying on t.NumMethod().
+type functionalMixin struct{}
+
+func (functionalMixin) Foo(args Args) error { return nil }
+
+type controlFlowMixin struct{}
+
+func (controlFlowMixin) Foo(args Args) error { return nil }
+
+type ambiguousDSL struct {
+	functionalMixin
+	controlFlowMixin
+}
+
+func TestNewEngineDetectsAmbiguousEmbeddedCollision(t *testing.T) {
+	_, err := NewEngine("", &ambiguousDSL{}, &DefaultParser{})
+	if err == nil {
+		t.Fatal("NewEngine succeeded with two embedded mixins defining the same verb, want an error")
+	}
+	if got := err.Error(); !strings.Contains(got, "foo") {
+		t.Errorf("error %q does not mention the colliding verb name", got)
+	}
+}
+
+// unambiguousDSL embeds two mixins that define distinct verbs, which must
+// still work: ambiguousEmbeddedCollisions should not flag methods that
+// don't collide.
+type loggingMixin struct{}
+
+func (loggingMixin) Log(args Args) error { return nil }
+
+type unambiguousDSL struct {
+	functionalMixin
+	loggingMixin
+}
+
+func TestNewEngineAllowsDistinctEmbeddedVerbs(t *testing.T) {
+	if _, err := NewEngine("", &unambiguousDSL{}, &DefaultParser{}); err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+}
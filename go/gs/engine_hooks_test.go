@@ -0,0 +1,63 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type hooksDSL struct{}
+
+func (hooksDSL) Play(args Args) error { return nil }
+func (hooksDSL) Stop(args Args) error { return nil }
+
+func TestBeforeAfterHooksCountInvocations(t *testing.T) {
+	e, err := NewEngine("", hooksDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	var before, after int
+	e.Before(func(call Call) error {
+		before++
+		return nil
+	})
+	e.After(func(call Call, err error) {
+		after++
+	})
+	if err := e.Execute(context.Background(), "play().stop()"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if before != 2 {
+		t.Errorf("before hook ran %d times, want 2", before)
+	}
+	if after != 2 {
+		t.Errorf("after hook ran %d times, want 2", after)
+	}
+}
+
+func TestBeforeHookVetoesMethod(t *testing.T) {
+	e, err := NewEngine("", hooksDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	wantErr := errors.New("stop is not allowed")
+	var played bool
+	e.Before(func(call Call) error {
+		if call.Name == "stop" {
+			return wantErr
+		}
+		return nil
+	})
+	e.After(func(call Call, err error) {
+		if call.Name == "play" {
+			played = true
+		}
+	})
+	err = e.Execute(context.Background(), "play().stop()")
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("Execute error = %v, want it to wrap %v", err, wantErr)
+	}
+	if !played {
+		t.Error("play's after hook never ran, want the call before the veto to still dispatch")
+	}
+}
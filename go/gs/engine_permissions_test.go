@@ -0,0 +1,58 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type permissionsDSL struct{}
+
+func (permissionsDSL) Play(args Args) error   { return nil }
+func (permissionsDSL) Delete(args Args) error { return nil }
+
+func TestAllowListRejectsMethodsOutsideIt(t *testing.T) {
+	e, err := NewEngine("", permissionsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.Allow("play")
+	if err := e.Execute(context.Background(), "play()"); err != nil {
+		t.Errorf("Execute(play): %v, want nil since play is allowed", err)
+	}
+	var denied *DeniedMethodError
+	if err := e.Execute(context.Background(), "delete()"); !errors.As(err, &denied) {
+		t.Errorf("Execute(delete) error = %v, want *DeniedMethodError since delete is not in the allow list", err)
+	}
+}
+
+func TestDenyListRejectsDeniedMethods(t *testing.T) {
+	e, err := NewEngine("", permissionsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.Deny("delete")
+	if err := e.Execute(context.Background(), "play()"); err != nil {
+		t.Errorf("Execute(play): %v, want nil since play is not denied", err)
+	}
+	var denied *DeniedMethodError
+	if err := e.Execute(context.Background(), "delete()"); !errors.As(err, &denied) {
+		t.Errorf("Execute(delete) error = %v, want *DeniedMethodError", err)
+	}
+}
+
+func TestDenyListWinsOverAllowList(t *testing.T) {
+	e, err := NewEngine("", permissionsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.Allow("play", "delete")
+	e.Deny("delete")
+	var denied *DeniedMethodError
+	if err := e.Execute(context.Background(), "delete()"); !errors.As(err, &denied) {
+		t.Errorf("Execute(delete) error = %v, want *DeniedMethodError even though delete is also allowed", err)
+	}
+	if err := e.Execute(context.Background(), "play()"); err != nil {
+		t.Errorf("Execute(play): %v, want nil", err)
+	}
+}
@@ -0,0 +1,73 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type strictArgsDSL struct{}
+
+func (strictArgsDSL) Greet(args Args) error { return nil }
+
+func TestStrictArgsRejectsUnknownArgument(t *testing.T) {
+	e, err := NewEngine("", strictArgsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgSchema("greet", ArgSpec{Name: "name", Kind: ValueString})
+	e.SetStrictArgs(true)
+	err = e.Execute(context.Background(), `greet(name="Ada", volume=5)`)
+	var schemaErr *ArgSchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Execute error = %v, want *ArgSchemaError", err)
+	}
+	if schemaErr.Arg != "volume" {
+		t.Errorf("ArgSchemaError.Arg = %q, want %q", schemaErr.Arg, "volume")
+	}
+}
+
+func TestStrictArgsRejectsMissingRequiredArgument(t *testing.T) {
+	e, err := NewEngine("", strictArgsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgSchema("greet", ArgSpec{Name: "name", Kind: ValueString, Required: true})
+	e.SetStrictArgs(true)
+	err = e.Execute(context.Background(), `greet()`)
+	var schemaErr *ArgSchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Execute error = %v, want *ArgSchemaError", err)
+	}
+	if schemaErr.Arg != "name" {
+		t.Errorf("ArgSchemaError.Arg = %q, want %q", schemaErr.Arg, "name")
+	}
+}
+
+func TestStrictArgsRejectsWrongType(t *testing.T) {
+	e, err := NewEngine("", strictArgsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgSchema("greet", ArgSpec{Name: "name", Kind: ValueString})
+	e.SetStrictArgs(true)
+	err = e.Execute(context.Background(), `greet(name=5)`)
+	var schemaErr *ArgSchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Execute error = %v, want *ArgSchemaError", err)
+	}
+	if schemaErr.Arg != "name" {
+		t.Errorf("ArgSchemaError.Arg = %q, want %q", schemaErr.Arg, "name")
+	}
+}
+
+func TestStrictArgsDefaultLenientUnchanged(t *testing.T) {
+	e, err := NewEngine("", strictArgsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetArgSchema("greet", ArgSpec{Name: "name", Kind: ValueString, Required: true})
+	if err := e.Execute(context.Background(), `greet(extra="whatever")`); err != nil {
+		t.Fatalf("Execute with strict args off: %v, want nil (schema should not be enforced)", err)
+	}
+}
@@ -0,0 +1,98 @@
+package gs
+
+import "fmt"
+
+// UnknownMethodError reports that a DSL program called a method the Engine
+// has no handler for. Suggestion is a "did you mean" guess produced by
+// suggestMethod and may be empty.
+type UnknownMethodError struct {
+	Method     string
+	Suggestion string
+}
+
+func (e *UnknownMethodError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("gs: unknown method %q (did you mean %q?)", e.Method, e.Suggestion)
+	}
+	return fmt.Sprintf("gs: unknown method %q", e.Method)
+}
+
+// ParseError reports a lexing or parsing failure at a specific rune offset
+// into the source, for callers that want to point a user at the offending
+// text (e.g. underlining it in an editor) rather than just reading a
+// message.
+type ParseError struct {
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("gs: %s (at position %d)", e.Msg, e.Pos)
+}
+
+// DeniedMethodError reports that a method is registered but blocked by the
+// Engine's allow/deny lists.
+type DeniedMethodError struct {
+	Method string
+	Reason string
+}
+
+func (e *DeniedMethodError) Error() string {
+	return fmt.Sprintf("gs: method %q %s", e.Method, e.Reason)
+}
+
+// BoundsError reports that a numeric argument fell outside the range
+// configured via Engine.SetArgBounds. It is returned instead of dispatching
+// the call, so a handler never sees the out-of-range value.
+type BoundsError struct {
+	Method   string
+	Arg      string
+	Value    float64
+	Min, Max float64
+}
+
+func (e *BoundsError) Error() string {
+	return fmt.Sprintf("gs: %s: argument %q value %g is outside the allowed range [%g, %g]", e.Method, e.Arg, e.Value, e.Min, e.Max)
+}
+
+// ArgSchemaError reports that a call's arguments violated the schema
+// registered via Engine.SetArgSchema while Engine.SetStrictArgs(true) is in
+// effect: an argument name the schema doesn't declare, a required argument
+// that is missing, or a declared argument whose value's Kind doesn't match.
+type ArgSchemaError struct {
+	Method string
+	Arg    string
+	Reason string
+}
+
+func (e *ArgSchemaError) Error() string {
+	return fmt.Sprintf("gs: %s: argument %q %s", e.Method, e.Arg, e.Reason)
+}
+
+// GrammarVersionError reports that a program's version(...) pragma declared
+// a version incompatible with the constraint configured via
+// Engine.RequireGrammarVersion, so it was not executed.
+type GrammarVersionError struct {
+	Declared   string
+	Constraint string
+}
+
+func (e *GrammarVersionError) Error() string {
+	return fmt.Sprintf("gs: program declares version %q, which does not satisfy required version %q", e.Declared, e.Constraint)
+}
+
+// HandlerError wraps the error returned by a dispatched method handler
+// together with the call that produced it, so callers can recover the
+// failing method name with errors.As instead of parsing the error string.
+type HandlerError struct {
+	Method string
+	Err    error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("gs: %s: %s", e.Method, e.Err)
+}
+
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
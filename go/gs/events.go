@@ -0,0 +1,35 @@
+package gs
+
+import "time"
+
+// EventKind identifies the phase of execution a structured Event describes.
+type EventKind string
+
+const (
+	EventCallStart EventKind = "call_start"
+	EventCallEnd   EventKind = "call_end"
+)
+
+// Event is a structured record of a single point in an Engine's execution,
+// suitable for tracing or logging.
+type Event struct {
+	Kind EventKind
+	Call Call
+	Err  error
+	At   time.Time
+}
+
+// EventHook receives structured Events as an Engine executes a program.
+type EventHook func(Event)
+
+// OnEvent registers a hook that receives every Event emitted during
+// Execute.
+func (e *Engine) OnEvent(hook EventHook) {
+	e.eventHooks = append(e.eventHooks, hook)
+}
+
+func (e *Engine) emit(evt Event) {
+	for _, hook := range e.eventHooks {
+		hook(evt)
+	}
+}
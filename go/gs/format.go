@@ -0,0 +1,35 @@
+package gs
+
+import "strings"
+
+// FormatGrammar returns grammar reformatted with consistent whitespace: each
+// rule trimmed to a single line with no leading/trailing space, continuation
+// alternatives (lines starting with "|") indented four spaces beneath the
+// rule they belong to, and runs of blank lines collapsed to one. It does not
+// otherwise change the grammar, so %-directives and comments pass through
+// unchanged.
+func FormatGrammar(grammar string) string {
+	lines := strings.Split(grammar, "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if !blank && len(out) > 0 {
+				out = append(out, "")
+			}
+			blank = true
+			continue
+		}
+		blank = false
+		if strings.HasPrefix(trimmed, "|") {
+			out = append(out, "    "+trimmed)
+		} else {
+			out = append(out, trimmed)
+		}
+	}
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
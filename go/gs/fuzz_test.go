@@ -0,0 +1,52 @@
+package gs
+
+import (
+	"strings"
+	"testing"
+)
+
+// FuzzParse exercises DefaultParser.Parse with arbitrary input. Parse is
+// documented to never panic, so the only thing this checks for is a panic;
+// a non-nil error is an entirely expected outcome for malformed input.
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"play()",
+		`play(track="intro")`,
+		"play(",
+		")))",
+		"[[[[[[[[",
+		`play(track="unterminated)`,
+		"play" + strings.Repeat("(", 1000),
+		"\xff\xfe",
+		"play(a=1, b=2).stop()",
+	} {
+		f.Add(seed)
+	}
+	p := &DefaultParser{AllowUnitSuffixes: true, AllowRangeLiterals: true}
+	f.Fuzz(func(t *testing.T, input string) {
+		p.Parse(input)
+	})
+}
+
+func TestParseDeepNestingReturnsErrorNotPanic(t *testing.T) {
+	p := &DefaultParser{}
+	input := strings.Repeat("a(", 1000) + strings.Repeat(")", 1000)
+	if _, err := p.Parse(input); err == nil {
+		t.Error("Parse succeeded on pathologically deep nesting, want a maximum-depth error")
+	}
+}
+
+func TestParseInvalidUTF8ReturnsErrorNotPanic(t *testing.T) {
+	p := &DefaultParser{}
+	if _, err := p.Parse("play(\xff\xfe)"); err == nil {
+		t.Error("Parse succeeded on invalid UTF-8, want an error")
+	}
+}
+
+func TestParseUnbalancedParensReturnsError(t *testing.T) {
+	p := &DefaultParser{}
+	if _, err := p.Parse("play("); err == nil {
+		t.Error("Parse succeeded on an unbalanced paren, want an error")
+	}
+}
@@ -0,0 +1,138 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+)
+
+// GoogleCFGProvider implements CFGProvider for Gemini's function-calling
+// API. Gemini has no dedicated CFG/grammar tool type, so BuildTool packages
+// the grammar as a function declaration whose parameter schema documents
+// the DSL's syntax for the model.
+type GoogleCFGProvider struct{}
+
+// BuildTool builds a Gemini function declaration payload carrying the
+// cleaned grammar.
+func (p *GoogleCFGProvider) BuildTool(toolName, description, grammar, syntax string) (map[string]any, error) {
+	if err := ValidateGrammar(grammar); err != nil {
+		return nil, err
+	}
+	if syntax == "" {
+		syntax = SyntaxLark
+	}
+	return map[string]any{
+		"name":        toolName,
+		"description": description,
+		"parameters": map[string]any{
+			"type": "OBJECT",
+			"properties": map[string]any{
+				"dsl_code": map[string]any{
+					"type":        "STRING",
+					"description": fmt.Sprintf("DSL code conforming to the following %s grammar:\n%s", syntax, CleanGrammarForCFG(grammar)),
+				},
+			},
+			"required": []string{"dsl_code"},
+		},
+	}, nil
+}
+
+// GetTextFormat returns an empty text format: Gemini has no separate
+// text-format switch for function-call-constrained generation.
+func (p *GoogleCFGProvider) GetTextFormat() map[string]any {
+	return map[string]any{}
+}
+
+// ValidateGrammarForSyntax checks grammar's general structure. Gemini has no
+// native grammar-constrained function-call type, so BuildTool only embeds
+// the grammar as descriptive text — any syntax label is accepted.
+func (p *GoogleCFGProvider) ValidateGrammarForSyntax(grammar, syntax string) error {
+	return ValidateGrammar(grammar)
+}
+
+// googleResponder is the minimal surface Generate needs from a Gemini
+// client. Callers pass an adapter around their SDK of choice (e.g.
+// google.golang.org/genai) as the client argument.
+type googleResponder interface {
+	GenerateContent(ctx context.Context, params map[string]any) (map[string]any, error)
+}
+
+// Generate calls Gemini's generateContent API with prompt sent as the sole
+// user turn, plus model, tools and kwargs merged into the request
+// parameters. client must implement googleResponder.
+func (p *GoogleCFGProvider) Generate(ctx context.Context, prompt, model string, tools []map[string]any, textFormat map[string]any, client interface{}, kwargs map[string]any) (interface{}, error) {
+	responder, ok := client.(googleResponder)
+	if !ok {
+		return nil, fmt.Errorf("gs: client must implement gs.googleResponder to use GoogleCFGProvider.Generate")
+	}
+	params := map[string]any{
+		"model": model,
+		"contents": []map[string]any{
+			{"role": "user", "parts": []map[string]any{{"text": prompt}}},
+		},
+		"tools": tools,
+	}
+	for k, v := range textFormat {
+		params[k] = v
+	}
+	for k, v := range kwargs {
+		params[k] = v
+	}
+	return responder.GenerateContent(ctx, params)
+}
+
+// ExtractDSLCode pulls the generated DSL code out of a Gemini
+// generateContent result. response may be a plain string, or a
+// map[string]any shaped like a GenerateContentResponse:
+// candidates[].content.parts[] is scanned for a functionCall's "dsl_code"
+// arg first (BuildTool's parameter schema names that field), falling back
+// to the first part's "text" field if no function call is present. The
+// result is passed through StripMarkdownFences, since Gemini may still wrap
+// a text-only answer in a code fence.
+func (p *GoogleCFGProvider) ExtractDSLCode(response interface{}) (string, error) {
+	switch r := response.(type) {
+	case string:
+		return StripMarkdownFences(r), nil
+	case map[string]any:
+		candidates, ok := r["candidates"].([]any)
+		if !ok {
+			return "", fmt.Errorf("gs: could not find DSL code in Gemini response")
+		}
+		var textFallback string
+		for _, c := range candidates {
+			candidate, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			content, ok := candidate["content"].(map[string]any)
+			if !ok {
+				continue
+			}
+			parts, ok := content["parts"].([]any)
+			if !ok {
+				continue
+			}
+			for _, pt := range parts {
+				part, ok := pt.(map[string]any)
+				if !ok {
+					continue
+				}
+				if call, ok := part["functionCall"].(map[string]any); ok {
+					if args, ok := call["args"].(map[string]any); ok {
+						if code, ok := args["dsl_code"].(string); ok {
+							return StripMarkdownFences(code), nil
+						}
+					}
+				}
+				if text, ok := part["text"].(string); ok && text != "" && textFallback == "" {
+					textFallback = text
+				}
+			}
+		}
+		if textFallback != "" {
+			return StripMarkdownFences(textFallback), nil
+		}
+		return "", fmt.Errorf("gs: could not find DSL code in Gemini response")
+	default:
+		return "", fmt.Errorf("gs: unsupported response type %T", response)
+	}
+}
@@ -0,0 +1,114 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+// sampleGeminiResponse mimics a Gemini generateContent response carrying a
+// functionCall part whose args has the dsl_code field BuildTool's
+// parameter schema names.
+func sampleGeminiResponse(dslCode string) map[string]any {
+	return map[string]any{
+		"candidates": []any{
+			map[string]any{
+				"content": map[string]any{
+					"role": "model",
+					"parts": []any{
+						map[string]any{
+							"functionCall": map[string]any{
+								"name": "emit_dsl",
+								"args": map[string]any{
+									"dsl_code": dslCode,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGoogleExtractDSLCodeFromFunctionCall(t *testing.T) {
+	p := &GoogleCFGProvider{}
+	got, err := p.ExtractDSLCode(sampleGeminiResponse(`play(track="intro")`))
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestGoogleExtractDSLCodeFallsBackToText(t *testing.T) {
+	p := &GoogleCFGProvider{}
+	response := map[string]any{
+		"candidates": []any{
+			map[string]any{
+				"content": map[string]any{
+					"parts": []any{
+						map[string]any{"text": "```\nplay(track=\"intro\")\n```"},
+					},
+				},
+			},
+		},
+	}
+	got, err := p.ExtractDSLCode(response)
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestGoogleExtractDSLCodeFromPlainString(t *testing.T) {
+	p := &GoogleCFGProvider{}
+	got, err := p.ExtractDSLCode("play(track=\"intro\")")
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestGoogleExtractDSLCodeErrorsWithoutCandidates(t *testing.T) {
+	p := &GoogleCFGProvider{}
+	if _, err := p.ExtractDSLCode(map[string]any{}); err == nil {
+		t.Error("ExtractDSLCode succeeded on a response with no candidates, want an error")
+	}
+}
+
+type fakeGoogleResponder struct {
+	gotParams map[string]any
+	response  map[string]any
+}
+
+func (f *fakeGoogleResponder) GenerateContent(ctx context.Context, params map[string]any) (map[string]any, error) {
+	f.gotParams = params
+	return f.response, nil
+}
+
+func TestGoogleGenerateUsesClient(t *testing.T) {
+	client := &fakeGoogleResponder{response: sampleGeminiResponse(`play(track="intro")`)}
+	p := &GoogleCFGProvider{}
+	result, err := p.Generate(context.Background(), "play the intro", "gemini-pro", nil, nil, client, nil)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if client.gotParams["model"] != "gemini-pro" {
+		t.Errorf("model = %v, want %q", client.gotParams["model"], "gemini-pro")
+	}
+	if result == nil {
+		t.Error("Generate returned a nil result")
+	}
+}
+
+func TestGoogleGenerateRejectsIncompatibleClient(t *testing.T) {
+	p := &GoogleCFGProvider{}
+	if _, err := p.Generate(context.Background(), "prompt", "gemini-pro", nil, nil, "not a client", nil); err == nil {
+		t.Error("Generate succeeded with an incompatible client, want an error")
+	}
+}
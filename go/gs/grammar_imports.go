@@ -0,0 +1,40 @@
+package gs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ResolveGrammarImports inlines Lark %import directives in grammar by
+// replacing each `%import name` (or `%import name -> alias`) line with the
+// text resolver returns for name, before CleanGrammarForCFG would otherwise
+// just strip the directive. resolver supplies the replacement definition
+// for each import, so this has no filesystem dependency of its own; a
+// caller can back it with an embedded FS, a fixed map of Lark stdlib rules,
+// or something else entirely. This lets modular grammars assembled from
+// multiple %import'd pieces be flattened into a single, self-contained CFG
+// definition for providers that only accept one.
+func ResolveGrammarImports(grammar string, resolver func(name string) (string, error)) (string, error) {
+	lines := strings.Split(grammar, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "%import") {
+			out = append(out, line)
+			continue
+		}
+		name := strings.TrimSpace(strings.TrimPrefix(trimmed, "%import"))
+		if idx := strings.Index(name, "->"); idx >= 0 {
+			name = strings.TrimSpace(name[:idx])
+		}
+		if name == "" {
+			return "", fmt.Errorf("gs: malformed %%import directive: %q", line)
+		}
+		resolved, err := resolver(name)
+		if err != nil {
+			return "", fmt.Errorf("gs: resolving import %q: %w", name, err)
+		}
+		out = append(out, resolved)
+	}
+	return strings.Join(out, "\n"), nil
+}
@@ -0,0 +1,40 @@
+package gs
+
+import (
+	"fmt"
+	"io/fs"
+)
+
+// LoadGrammar reads the grammar at path from fsys and validates it with
+// ValidateGrammar before returning it, so a malformed //go:embed'd grammar
+// fails fast at startup instead of surfacing as a confusing parse error the
+// first time a DSL program runs. fsys is typically an embed.FS populated by
+// a `//go:embed grammar.lark` directive, giving apps a standard loading path
+// instead of pasting the grammar into a Go string constant. Errors wrap the
+// path so a startup failure in an app embedding many grammars says which
+// one is broken.
+func LoadGrammar(fsys fs.FS, path string) (string, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return "", fmt.Errorf("gs: loading grammar %q: %w", path, err)
+	}
+	grammar := string(data)
+	if err := ValidateGrammar(grammar); err != nil {
+		return "", fmt.Errorf("gs: loading grammar %q: %w", path, err)
+	}
+	return grammar, nil
+}
+
+// BuildOpenAICFGToolFromFile loads the grammar at path from fsys via
+// LoadGrammar and builds an OpenAI CFG tool payload from it, using the rest
+// of config (ToolName, Description, Syntax) as-is. config.Grammar is
+// ignored and overwritten by the loaded file, since supplying both would
+// leave it unclear which one wins.
+func BuildOpenAICFGToolFromFile(fsys fs.FS, path string, config CFGConfig) (map[string]any, error) {
+	grammar, err := LoadGrammar(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	config.Grammar = grammar
+	return BuildOpenAICFGTool(config)
+}
@@ -0,0 +1,65 @@
+package gs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// CompileActions is sugar over Engine.Compile for tests doing TDD against an
+// Action-returning DSL: it reads the same as AssertActions at a call site
+// without a reader having to know Compile is a method.
+func CompileActions(e *Engine, code string) ([]Action, error) {
+	return e.Compile(code)
+}
+
+// AssertActions compiles code against e via CompileActions and fails t if
+// the result doesn't deterministically match want: same length, and for
+// each index the same Action.Kind and an equal Action.Payload (map key
+// order never matters, since Go map equality already ignores it).
+func AssertActions(t *testing.T, e *Engine, code string, want []Action) {
+	t.Helper()
+	got, err := CompileActions(e, code)
+	if err != nil {
+		t.Fatalf("gs: compiling %q: %v", code, err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("gs: %q produced %d actions, want %d: got %+v", code, len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].Kind != want[i].Kind {
+			t.Errorf("gs: action %d: kind = %q, want %q", i, got[i].Kind, want[i].Kind)
+			continue
+		}
+		if !reflect.DeepEqual(got[i].Payload, want[i].Payload) {
+			t.Errorf("gs: action %d (%s): payload = %+v, want %+v", i, got[i].Kind, got[i].Payload, want[i].Payload)
+		}
+	}
+}
+
+// ParserFromGrammar builds a Parser from grammar using NewEBNFParser, after
+// first running grammar through CleanGrammarForCFG to strip the %import and
+// %ignore directives and comments a CFG-oriented grammar string may still
+// carry. This lets the same grammar text used for BuildOpenAICFGTool double
+// as the source of truth for an actual parser, instead of drifting apart as
+// two independently maintained things.
+func ParserFromGrammar(grammar string) (Parser, error) {
+	return NewEBNFParser(CleanGrammarForCFG(grammar))
+}
+
+// AssertGrammarAcceptsSamples builds a Parser from grammar via
+// ParserFromGrammar and fails t if any of samples does not parse. It exists
+// so DSL authors can assert their grammar actually accepts the example
+// programs they expect, catching grammar/implementation drift - the whole
+// point of constraining an LLM with the grammar in the first place.
+func AssertGrammarAcceptsSamples(t *testing.T, grammar string, samples []string) {
+	t.Helper()
+	parser, err := ParserFromGrammar(grammar)
+	if err != nil {
+		t.Fatalf("gs: building parser from grammar: %v", err)
+	}
+	for _, sample := range samples {
+		if _, err := parser.Parse(sample); err != nil {
+			t.Errorf("gs: grammar rejected expected sample %q: %v", sample, err)
+		}
+	}
+}
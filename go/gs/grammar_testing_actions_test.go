@@ -0,0 +1,56 @@
+package gs
+
+import "testing"
+
+type compileActionsDSL struct {
+	ran bool
+}
+
+func (d *compileActionsDSL) Play(args Args) error {
+	d.ran = true
+	return nil
+}
+
+func TestCompileActionsConvertsCallsWithoutDispatching(t *testing.T) {
+	dsl := &compileActionsDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	actions, err := CompileActions(e, `play(track="intro")`)
+	if err != nil {
+		t.Fatalf("CompileActions: %v", err)
+	}
+	if len(actions) != 1 || actions[0].Kind != "play" {
+		t.Fatalf("actions = %+v, want a single play action", actions)
+	}
+	if got, ok := actions[0].Payload["track"].(Value); !ok || !got.Equal(NewStringValue("intro")) {
+		t.Errorf("Payload[track] = %v, want the parsed Value for \"intro\"", got)
+	}
+	if dsl.ran {
+		t.Error("CompileActions invoked the method handler, want it to only describe the call")
+	}
+}
+
+func TestAssertActionsComparesKindAndPayload(t *testing.T) {
+	e, err := NewEngine("", &compileActionsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	AssertActions(t, e, `play(track="intro")`, []Action{
+		{Kind: "play", Payload: map[string]interface{}{"track": NewStringValue("intro")}},
+	})
+}
+
+func TestAssertActionsIgnoresPayloadKeyOrder(t *testing.T) {
+	e, err := NewEngine("", &compileActionsDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	AssertActions(t, e, `play(track="intro", volume=5)`, []Action{
+		{Kind: "play", Payload: map[string]interface{}{
+			"volume": NewNumberValue(5),
+			"track":  NewStringValue("intro"),
+		}},
+	})
+}
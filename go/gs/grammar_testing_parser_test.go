@@ -0,0 +1,34 @@
+package gs
+
+import "testing"
+
+const sampleCallGrammar = `
+start: call_chain
+call_chain: call ("." call)*
+call: NAME "(" ")"
+`
+
+func TestParserFromGrammarBuildsWorkingParser(t *testing.T) {
+	parser, err := ParserFromGrammar(sampleCallGrammar)
+	if err != nil {
+		t.Fatalf("ParserFromGrammar: %v", err)
+	}
+	chain, err := parser.Parse("play().stop()")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 2 || chain.Calls[0].Name != "play" || chain.Calls[1].Name != "stop" {
+		t.Errorf("Calls = %+v, want [play stop]", chain.Calls)
+	}
+}
+
+func TestParserFromGrammarStripsCFGDirectives(t *testing.T) {
+	grammar := sampleCallGrammar + "\n%import common.WS\n%ignore WS"
+	if _, err := ParserFromGrammar(grammar); err != nil {
+		t.Fatalf("ParserFromGrammar with CFG directives: %v", err)
+	}
+}
+
+func TestAssertGrammarAcceptsSamplesPasses(t *testing.T) {
+	AssertGrammarAcceptsSamples(t, sampleCallGrammar, []string{"play()", "play().stop()"})
+}
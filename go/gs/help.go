@@ -0,0 +1,59 @@
+package gs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// helpArgName is the optional keyword argument the built-in help verb reads
+// to describe a single method instead of listing all of them, e.g.
+// help(method="track").
+const helpArgName = "method"
+
+// SetHelpEnabled opts the Engine into a built-in "help" verb, handy for
+// interactive REPL-style DSL sessions (see Engine.REPL): help() returns a
+// description of every registered method (see DescribeMethods), while
+// help(method="track") returns just that one's. It only takes effect for
+// calls that don't already resolve to a method the DSL itself registered
+// under the name "help" (of any handler type) - a DSL's own help method
+// always wins over the built-in one.
+func (e *Engine) SetHelpEnabled(enabled bool) {
+	e.helpEnabled = enabled
+}
+
+// builtinHelp implements the built-in help verb as a ResultHandler.
+func (e *Engine) builtinHelp(args Args) (Value, error) {
+	name, named := args[helpArgName]
+	if !named {
+		if primary, ok := args.Primary(); ok {
+			name = primary
+			named = true
+		}
+	}
+	if named {
+		if name.Kind != ValueString && name.Kind != ValueIdentifier {
+			return Value{}, fmt.Errorf("gs: help: %q argument must be a string", helpArgName)
+		}
+		key := e.methodKey(name.Str)
+		for _, info := range e.DescribeMethods() {
+			if info.Name == key {
+				return NewStringValue(describeMethodInfo(info)), nil
+			}
+		}
+		return Value{}, fmt.Errorf("gs: help: no such method %q", name.Str)
+	}
+	infos := e.DescribeMethods()
+	lines := make([]string, len(infos))
+	for i, info := range infos {
+		lines[i] = describeMethodInfo(info)
+	}
+	return NewStringValue(strings.Join(lines, "\n")), nil
+}
+
+// describeMethodInfo renders a MethodInfo as one human-readable line.
+func describeMethodInfo(info MethodInfo) string {
+	if info.Description == "" {
+		return info.Name
+	}
+	return fmt.Sprintf("%s - %s", info.Name, info.Description)
+}
@@ -0,0 +1,436 @@
+package gs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokBool
+	tokLParen
+	tokRParen
+	tokComma
+	tokEquals
+	tokDot
+	tokNull
+	tokPlus
+	tokMinus
+	tokStar
+	tokSlash
+	tokBang
+	tokLBracket
+	tokRBracket
+	tokRange
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// numberUnits is the set of letter suffixes lexNumber accepts directly
+// after a numeric literal when allowUnits is true (see DefaultParser.
+// AllowUnitSuffixes), e.g. "250ms" or "8bars". It mixes time units (ms, s,
+// m, h, and their longer spellings) with musical ones (bars, beats) since
+// both are common in automation and music DSLs; Value.AsDuration rejects
+// the non-temporal ones. "%" (as in "80%") is handled separately in
+// lexNumber, since it is a symbol rather than a letter run; see
+// Value.AsPercent.
+var numberUnits = map[string]bool{
+	"ms": true, "s": true, "sec": true, "secs": true, "second": true, "seconds": true,
+	"m": true, "min": true, "mins": true, "minute": true, "minutes": true,
+	"h": true, "hr": true, "hrs": true, "hour": true, "hours": true,
+	"bar": true, "bars": true, "beat": true, "beats": true,
+}
+
+// splitNumberUnit splits a tokNumber's text (e.g. "250ms", "80%", "1.5e3")
+// into its numeric prefix ("250", "80", "1.5e3") and unit suffix ("ms",
+// "%", ""), the inverse of the suffix consumption lexNumber performs when
+// allowUnits is true. It skips over a valid e/E exponent (with its
+// optional sign and digits) before looking for a letter or "%" suffix, so
+// an exponential literal like "1.5e3" isn't mistaken for "1.5" plus the
+// bogus unit "e3". A plain number with no suffix returns an empty unit.
+func splitNumberUnit(text string) (numText, unit string) {
+	runes := []rune(text)
+	i := 0
+	for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+		i++
+	}
+	if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+		j := i + 1
+		if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+			j++
+		}
+		if j < len(runes) && unicode.IsDigit(runes[j]) {
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			i = j
+		}
+	}
+	if i >= len(runes) {
+		return text, ""
+	}
+	return string(runes[:i]), string(runes[i:])
+}
+
+// lex tokenizes DefaultParser source into a flat token stream. allowUnits
+// enables unit-suffix parsing on numeric literals (see
+// DefaultParser.AllowUnitSuffixes); plain DSLs leave it false and are
+// unaffected. meta collects "key: value" lines from any comments preceding
+// the first real token, for DefaultParser.Parse to attach to the first
+// parsed Call as its Meta (see Call.Meta); it is nil if there were none.
+func lex(input string, allowUnits bool) (tokens []token, meta map[string]string, err error) {
+	runes := []rune(input)
+	leading := true
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		tokensBefore := len(tokens)
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || (r == '/' && i+1 < len(runes) && runes[i+1] == '/'):
+			start := i
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			if leading {
+				text := strings.TrimLeft(string(runes[start:i]), "#/")
+				if key, value, ok := strings.Cut(text, ":"); ok {
+					if key = strings.TrimSpace(key); key != "" {
+						if meta == nil {
+							meta = make(map[string]string)
+						}
+						meta[key] = strings.TrimSpace(value)
+					}
+				}
+			}
+		case r == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{tokComma, ",", i})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{tokEquals, "=", i})
+			i++
+		case r == '.' && i+1 < len(runes) && runes[i+1] == '.':
+			tokens = append(tokens, token{tokRange, "..", i})
+			i += 2
+		case r == '.':
+			if i+1 < len(runes) && unicode.IsDigit(runes[i+1]) {
+				num, n, err := lexNumber(runes[i:], allowUnits)
+				if err != nil {
+					return nil, nil, err
+				}
+				tokens = append(tokens, token{tokNumber, num, i})
+				i += n
+				leading = false
+				continue
+			}
+			tokens = append(tokens, token{tokDot, ".", i})
+			i++
+		case r == '"' && i+2 < len(runes) && runes[i+1] == '"' && runes[i+2] == '"':
+			str, n, err := lexTripleString(runes[i:], i)
+			if err != nil {
+				return nil, nil, err
+			}
+			tokens = append(tokens, token{tokString, str, i})
+			i += n
+		case r == '"' || r == '\'':
+			str, n, err := lexString(runes[i:])
+			if err != nil {
+				return nil, nil, err
+			}
+			tokens = append(tokens, token{tokString, str, i})
+			i += n
+		case r == '+':
+			tokens = append(tokens, token{tokPlus, "+", i})
+			i++
+		case r == '-':
+			tokens = append(tokens, token{tokMinus, "-", i})
+			i++
+		case r == '*':
+			tokens = append(tokens, token{tokStar, "*", i})
+			i++
+		case r == '/':
+			tokens = append(tokens, token{tokSlash, "/", i})
+			i++
+		case r == '!':
+			tokens = append(tokens, token{tokBang, "!", i})
+			i++
+		case r == '[':
+			tokens = append(tokens, token{tokLBracket, "[", i})
+			i++
+		case r == '`':
+			ident, n, err := lexQuotedIdent(runes[i:], i)
+			if err != nil {
+				return nil, nil, err
+			}
+			tokens = append(tokens, token{tokIdent, ident, i})
+			i += n
+		case r == ']':
+			tokens = append(tokens, token{tokRBracket, "]", i})
+			i++
+		case unicode.IsDigit(r):
+			num, n, err := lexNumber(runes[i:], allowUnits)
+			if err != nil {
+				return nil, nil, err
+			}
+			tokens = append(tokens, token{tokNumber, num, i})
+			i += n
+		case unicode.IsLetter(r) || r == '_':
+			// "yes"/"on" and "no"/"off" are accepted as boolean literals
+			// alongside "true"/"false", since LLM-generated DSL source
+			// commonly uses them interchangeably; the token text is
+			// normalized to "true"/"false" so the parser only has to
+			// handle one spelling.
+			ident, n := lexIdent(runes[i:])
+			i += n
+			switch ident {
+			case "true", "yes", "on":
+				tokens = append(tokens, token{tokBool, "true", i})
+			case "false", "no", "off":
+				tokens = append(tokens, token{tokBool, "false", i})
+			case "null", "none":
+				tokens = append(tokens, token{tokNull, ident, i})
+			default:
+				tokens = append(tokens, token{tokIdent, ident, i})
+			}
+		default:
+			return nil, nil, fmt.Errorf("gs: unexpected character %q at position %d", r, i)
+		}
+		if len(tokens) > tokensBefore {
+			leading = false
+		}
+	}
+	tokens = append(tokens, token{tokEOF, "", len(runes)})
+	return tokens, meta, nil
+}
+
+func lexIdent(runes []rune) (string, int) {
+	j := 0
+	for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+		j++
+	}
+	return string(runes[:j]), j
+}
+
+// lexNumber scans an optionally-fractional, optionally-exponential numeric
+// literal, e.g. "42", "3.14", "1.5e3", "2E-4". Negation is handled by the
+// parser as a unary operator, not here. When allowUnits is true, a known
+// unit suffix immediately following the digits (e.g. "250ms", "8bars") is
+// consumed as part of the literal; an unrecognized suffix is an error
+// rather than being left for the lexer to (mis)interpret as a separate
+// identifier token.
+func lexNumber(runes []rune, allowUnits bool) (string, int, error) {
+	j := 0
+	for j < len(runes) && unicode.IsDigit(runes[j]) {
+		j++
+	}
+	if j < len(runes) && runes[j] == '.' && j+1 < len(runes) && unicode.IsDigit(runes[j+1]) {
+		j++
+		for j < len(runes) && unicode.IsDigit(runes[j]) {
+			j++
+		}
+	}
+	if j == 0 {
+		return "", 0, fmt.Errorf("gs: invalid number literal")
+	}
+	if j < len(runes) && (runes[j] == 'e' || runes[j] == 'E') {
+		k := j + 1
+		if k < len(runes) && (runes[k] == '+' || runes[k] == '-') {
+			k++
+		}
+		if k < len(runes) && unicode.IsDigit(runes[k]) {
+			for k < len(runes) && unicode.IsDigit(runes[k]) {
+				k++
+			}
+			j = k
+		}
+	}
+	if allowUnits {
+		k := j
+		for k < len(runes) && unicode.IsLetter(runes[k]) {
+			k++
+		}
+		switch {
+		case k > j:
+			suffix := string(runes[j:k])
+			if !numberUnits[suffix] {
+				return "", 0, fmt.Errorf("gs: unknown unit suffix %q", suffix)
+			}
+			j = k
+		case k < len(runes) && runes[k] == '%':
+			j = k + 1
+		}
+	}
+	return string(runes[:j]), j, nil
+}
+
+// lexString scans a quoted string literal, resolving backslash escapes
+// (\\", \\', \\\\, \\n, \\t, \\r, \\uXXXX) as it goes.
+func lexString(runes []rune) (string, int, error) {
+	quote := runes[0]
+	var sb strings.Builder
+	j := 1
+	for j < len(runes) {
+		switch runes[j] {
+		case quote:
+			return sb.String(), j + 1, nil
+		case '\\':
+			if j+1 >= len(runes) {
+				return "", 0, fmt.Errorf("gs: unterminated escape sequence in string literal")
+			}
+			if runes[j+1] == 'u' {
+				decoded, consumed, err := decodeUnicodeEscape(runes[j+1:])
+				if err != nil {
+					return "", 0, err
+				}
+				sb.WriteRune(decoded)
+				j += 1 + consumed
+				continue
+			}
+			escaped, err := unescape(runes[j+1])
+			if err != nil {
+				return "", 0, err
+			}
+			sb.WriteRune(escaped)
+			j += 2
+		default:
+			sb.WriteRune(runes[j])
+			j++
+		}
+	}
+	return "", 0, fmt.Errorf("gs: unterminated string literal")
+}
+
+// decodeUnicodeEscape decodes a \uXXXX escape, and - when it forms a valid
+// UTF-16 surrogate pair with an immediately following \uXXXX - the second
+// escape too, so a code point outside the Basic Multilingual Plane (e.g. an
+// emoji) round-trips correctly. runes must begin with the 'u' immediately
+// after the backslash lexString already consumed. consumed counts runes
+// from (and including) that 'u': 5 for a single escape, 11 when a
+// surrogate pair was decoded.
+func decodeUnicodeEscape(runes []rune) (rune, int, error) {
+	hi, err := parseHex4(runes, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !utf16.IsSurrogate(rune(hi)) {
+		return rune(hi), 5, nil
+	}
+	if len(runes) >= 11 && runes[5] == '\\' && runes[6] == 'u' {
+		if lo, err := parseHex4(runes, 7); err == nil {
+			if decoded := utf16.DecodeRune(rune(hi), rune(lo)); decoded != unicode.ReplacementChar {
+				return decoded, 11, nil
+			}
+		}
+	}
+	return 0, 0, fmt.Errorf("gs: unpaired surrogate \\u%04x in string literal", hi)
+}
+
+// parseHex4 parses the 4 hex digits of runes starting at start (e.g. the
+// "00e9" in "u00e9") into their integer value.
+func parseHex4(runes []rune, start int) (int, error) {
+	if start+4 > len(runes) {
+		return 0, fmt.Errorf("gs: incomplete \\u escape in string literal")
+	}
+	n, err := strconv.ParseInt(string(runes[start:start+4]), 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("gs: invalid \\u escape %q", string(runes[start:start+4]))
+	}
+	return int(n), nil
+}
+
+// lexTripleString scans a triple-double-quoted string literal
+// ("""..."""), for DSLs that embed multi-line freeform content like prompts
+// or lyrics. Unlike lexString, it is raw: no backslash escapes are
+// processed and internal newlines are preserved verbatim in the returned
+// text. runes must begin with the opening """; start is that opening
+// quote's position in the overall input, used to report an unterminated
+// literal's start via ParseError rather than just where scanning gave up.
+func lexTripleString(runes []rune, start int) (string, int, error) {
+	j := 3
+	for j+2 < len(runes) {
+		if runes[j] == '"' && runes[j+1] == '"' && runes[j+2] == '"' {
+			return string(runes[3:j]), j + 3, nil
+		}
+		j++
+	}
+	return "", 0, &ParseError{Pos: start, Msg: "unterminated triple-quoted string literal"}
+}
+
+// lexQuotedIdent scans a backtick-quoted identifier, e.g. `` `add clip` ``,
+// letting a method or argument name contain spaces or a reserved word that
+// wouldn't otherwise lex as a bare identifier. runes must begin with the
+// opening backtick; start is its position, for reporting an unterminated
+// literal via ParseError. The text between backticks is normalized the same
+// way a Go method name becomes its snake_case DSL verb (toSnakeCase already
+// lowercases via Engine.methodKey's default case-insensitivity), so any run
+// of whitespace becomes a single underscore: `` `add clip` `` and
+// `add_clip` name the same thing.
+func lexQuotedIdent(runes []rune, start int) (string, int, error) {
+	j := 1
+	for j < len(runes) && runes[j] != '`' {
+		j++
+	}
+	if j >= len(runes) {
+		return "", 0, &ParseError{Pos: start, Msg: "unterminated quoted identifier"}
+	}
+	return normalizeQuotedIdent(string(runes[1:j])), j + 1, nil
+}
+
+// normalizeQuotedIdent collapses any run of whitespace in s into a single
+// underscore and trims leading/trailing whitespace, so a backtick-quoted
+// identifier lines up with the snake_case name its Go method counterpart
+// would register under.
+func normalizeQuotedIdent(s string) string {
+	var sb strings.Builder
+	inSpace := false
+	for _, r := range strings.TrimSpace(s) {
+		if unicode.IsSpace(r) {
+			inSpace = true
+			continue
+		}
+		if inSpace {
+			sb.WriteByte('_')
+			inSpace = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func unescape(r rune) (rune, error) {
+	switch r {
+	case '"':
+		return '"', nil
+	case '\'':
+		return '\'', nil
+	case '\\':
+		return '\\', nil
+	case 'n':
+		return '\n', nil
+	case 't':
+		return '\t', nil
+	case 'r':
+		return '\r', nil
+	default:
+		return 0, fmt.Errorf("gs: unsupported escape sequence \\%c", r)
+	}
+}
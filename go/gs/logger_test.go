@@ -0,0 +1,82 @@
+package gs
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+// capturingHandler is a minimal slog.Handler that records every record's
+// message and attributes, so a test can assert on structured log output
+// without parsing text.
+type capturingHandler struct {
+	records []slog.Record
+}
+
+func (h *capturingHandler) Enabled(ctx context.Context, level slog.Level) bool { return true }
+
+func (h *capturingHandler) Handle(ctx context.Context, r slog.Record) error {
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *capturingHandler) WithAttrs(attrs []slog.Attr) slog.Handler { return h }
+func (h *capturingHandler) WithGroup(name string) slog.Handler       { return h }
+
+func (h *capturingHandler) attr(r slog.Record, key string) (slog.Value, bool) {
+	var found slog.Value
+	var ok bool
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == key {
+			found, ok = a.Value, true
+			return false
+		}
+		return true
+	})
+	return found, ok
+}
+
+type loggerDSL struct{}
+
+func (loggerDSL) Play(args Args) error { return nil }
+
+func TestSetLoggerLogsDispatchFields(t *testing.T) {
+	h := &capturingHandler{}
+	e, err := NewEngine("", loggerDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetLogger(slog.New(h))
+	if err := e.Execute(context.Background(), `play(track="intro")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	var dispatch *slog.Record
+	for i := range h.records {
+		if h.records[i].Message == "gs: dispatch" {
+			dispatch = &h.records[i]
+			break
+		}
+	}
+	if dispatch == nil {
+		t.Fatalf("no \"gs: dispatch\" record logged, got %d records", len(h.records))
+	}
+	if method, ok := h.attr(*dispatch, "method"); !ok || method.String() != "play" {
+		t.Errorf("method attr = %v (ok=%v), want %q", method, ok, "play")
+	}
+	if _, ok := h.attr(*dispatch, "arg_count"); !ok {
+		t.Error("dispatch record missing arg_count attr")
+	}
+	if _, ok := h.attr(*dispatch, "duration"); !ok {
+		t.Error("dispatch record missing duration attr")
+	}
+}
+
+func TestNilLoggerDisablesLogging(t *testing.T) {
+	e, err := NewEngine("", loggerDSL{}, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Execute(context.Background(), "play()"); err != nil {
+		t.Fatalf("Execute with no logger configured: %v", err)
+	}
+}
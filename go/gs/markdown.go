@@ -0,0 +1,21 @@
+package gs
+
+import "strings"
+
+// StripMarkdownFences removes a single leading and trailing Markdown code
+// fence (``` or ```lang ... ```) from s, if present, and trims surrounding
+// whitespace. It is a no-op on text that isn't fenced. This is useful when
+// extracting DSL code from an LLM response, since models often wrap code in
+// a fence even when asked to return raw source.
+func StripMarkdownFences(s string) string {
+	trimmed := strings.TrimSpace(s)
+	if !strings.HasPrefix(trimmed, "```") {
+		return trimmed
+	}
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) < 2 || !strings.HasPrefix(strings.TrimSpace(lines[len(lines)-1]), "```") {
+		return trimmed
+	}
+	body := lines[1 : len(lines)-1]
+	return strings.TrimSpace(strings.Join(body, "\n"))
+}
@@ -0,0 +1,38 @@
+package gs
+
+import "testing"
+
+func TestStripMarkdownFencesPlainFence(t *testing.T) {
+	got := StripMarkdownFences("```\nplay(track=\"intro\")\n```")
+	if got != `play(track="intro")` {
+		t.Errorf("StripMarkdownFences = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestStripMarkdownFencesWithLanguageTag(t *testing.T) {
+	got := StripMarkdownFences("```dsl\nplay(track=\"intro\")\n```")
+	if got != `play(track="intro")` {
+		t.Errorf("StripMarkdownFences = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestStripMarkdownFencesUnfencedInput(t *testing.T) {
+	got := StripMarkdownFences(`play(track="intro")`)
+	if got != `play(track="intro")` {
+		t.Errorf("StripMarkdownFences = %q, want the input returned unchanged", got)
+	}
+}
+
+func TestStripMarkdownFencesTrimsSurroundingWhitespace(t *testing.T) {
+	got := StripMarkdownFences("  \n```\nplay()\n```\n  ")
+	if got != "play()" {
+		t.Errorf("StripMarkdownFences = %q, want %q", got, "play()")
+	}
+}
+
+func TestStripMarkdownFencesMultilineBody(t *testing.T) {
+	got := StripMarkdownFences("```\nplay()\n.stop()\n```")
+	if got != "play()\n.stop()" {
+		t.Errorf("StripMarkdownFences = %q, want %q", got, "play()\n.stop()")
+	}
+}
@@ -0,0 +1,60 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+// mixedHandlersDSL answers to both handler styles the Engine supports: Log
+// executes directly (func(Args) error), while CreateTrack only describes
+// the work as Actions (func(Args) ([]Action, error)) for a Runtime to
+// perform.
+type mixedHandlersDSL struct {
+	logged []string
+}
+
+func (d *mixedHandlersDSL) Log(args Args) error {
+	msg, _ := args.GetString("msg")
+	d.logged = append(d.logged, msg)
+	return nil
+}
+
+func (d *mixedHandlersDSL) CreateTrack(args Args) ([]Action, error) {
+	return []Action{{Kind: "create_track", Payload: args.ToPayload()}}, nil
+}
+
+func TestEngineDispatchesErrorAndActionHandlersOnOneDSL(t *testing.T) {
+	dsl := &mixedHandlersDSL{}
+	rt := NewRecordingRuntime()
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetRuntime(rt)
+
+	if err := e.Execute(context.Background(), `log(msg="hi").create_track(name="intro")`); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if len(dsl.logged) != 1 || dsl.logged[0] != "hi" {
+		t.Errorf("logged = %v, want [\"hi\"] from the direct-execution handler", dsl.logged)
+	}
+	tracks := rt.ActionsOfKind("create_track")
+	if len(tracks) != 1 {
+		t.Fatalf("create_track actions = %d, want 1 from the Action-returning handler", len(tracks))
+	}
+	if tracks[0].Payload["name"] != "intro" {
+		t.Errorf("create_track payload = %v, want name=intro", tracks[0].Payload)
+	}
+}
+
+func TestEngineActionHandlerErrorsWithoutRuntime(t *testing.T) {
+	dsl := &mixedHandlersDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.Execute(context.Background(), `create_track(name="intro")`); err == nil {
+		t.Error("Execute succeeded on an Action-returning method with no Runtime configured, want an error")
+	}
+}
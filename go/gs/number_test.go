@@ -0,0 +1,64 @@
+package gs
+
+import "testing"
+
+func TestLexNumberExponent(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"simple exponent", "1.5e3", "1.5e3"},
+		{"uppercase exponent", "2E4", "2E4"},
+		{"negative exponent", "2E-4", "2E-4"},
+		{"positive exponent sign", "5e+2", "5e+2"},
+		{"integer exponent", "1e10", "1e10"},
+		{"no exponent", "42", "42"},
+		{"dangling e is not an exponent", "1e", "1"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			text, n, err := lexNumber([]rune(tc.in), false)
+			if err != nil {
+				t.Fatalf("lexNumber(%q) returned error: %v", tc.in, err)
+			}
+			if text != tc.want {
+				t.Errorf("lexNumber(%q) = %q, want %q", tc.in, text, tc.want)
+			}
+			if n != len(tc.want) {
+				t.Errorf("lexNumber(%q) consumed %d runes, want %d", tc.in, n, len(tc.want))
+			}
+		})
+	}
+}
+
+func TestParseExponentLiteral(t *testing.T) {
+	chain, err := (&DefaultParser{}).Parse(`track(freq=1.5e3)`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	v := chain.Calls[0].Args[0].Value
+	if v.Num != 1500 {
+		t.Errorf("Num = %v, want 1500", v.Num)
+	}
+	if !v.IsFloat {
+		t.Errorf("IsFloat = false, want true for an exponential literal")
+	}
+}
+
+func TestParseUnaryPlus(t *testing.T) {
+	chain, err := (&DefaultParser{}).Parse(`point(x=+5)`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	v := chain.Calls[0].Args[0].Value
+	if v.Kind != ValueNumber || v.Num != 5 {
+		t.Errorf("x = %+v, want ValueNumber 5", v)
+	}
+}
+
+func TestParseUnaryPlusRequiresNumber(t *testing.T) {
+	if _, err := (&DefaultParser{}).Parse(`flag(enabled=+true)`); err == nil {
+		t.Error("Parse(enabled=+true) succeeded, want an error")
+	}
+}
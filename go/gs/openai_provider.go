@@ -0,0 +1,111 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+)
+
+// OpenAICFGProvider implements CFGProvider for OpenAI's CFG-constrained
+// generation API.
+type OpenAICFGProvider struct{}
+
+// BuildTool builds an OpenAI CFG tool payload.
+func (p *OpenAICFGProvider) BuildTool(toolName, description, grammar, syntax string) (map[string]any, error) {
+	return BuildOpenAICFGTool(CFGConfig{
+		ToolName:    toolName,
+		Description: description,
+		Grammar:     grammar,
+		Syntax:      syntax,
+	})
+}
+
+// GetTextFormat returns the text format configuration for OpenAI CFG
+// requests.
+func (p *OpenAICFGProvider) GetTextFormat() map[string]any {
+	return GetOpenAITextFormatForCFG()
+}
+
+// ValidateGrammarForSyntax checks grammar's general structure and confirms
+// syntax is one OpenAI's CFG tool natively supports ("lark" or "regex"); an
+// empty syntax is treated as the default, SyntaxLark.
+func (p *OpenAICFGProvider) ValidateGrammarForSyntax(grammar, syntax string) error {
+	if err := ValidateGrammar(grammar); err != nil {
+		return err
+	}
+	switch syntax {
+	case "", SyntaxLark, SyntaxRegex:
+		return nil
+	default:
+		return fmt.Errorf("gs: OpenAI CFG tools support %q or %q syntax, got %q", SyntaxLark, SyntaxRegex, syntax)
+	}
+}
+
+// openAIResponder is the minimal surface Generate needs from an OpenAI
+// client. Callers pass an adapter around their SDK of choice (e.g.
+// github.com/openai/openai-go) as the client argument.
+type openAIResponder interface {
+	CreateResponse(ctx context.Context, params map[string]any) (map[string]any, error)
+}
+
+// Generate calls the OpenAI Responses API with prompt, model, tools and
+// textFormat merged into the request parameters along with kwargs. client
+// must implement openAIResponder.
+func (p *OpenAICFGProvider) Generate(ctx context.Context, prompt, model string, tools []map[string]any, textFormat map[string]any, client interface{}, kwargs map[string]any) (interface{}, error) {
+	responder, ok := client.(openAIResponder)
+	if !ok {
+		return nil, fmt.Errorf("gs: client must implement gs.openAIResponder to use OpenAICFGProvider.Generate")
+	}
+	params := map[string]any{
+		"model": model,
+		"input": prompt,
+		"tools": tools,
+	}
+	for k, v := range textFormat {
+		params[k] = v
+	}
+	for k, v := range kwargs {
+		params[k] = v
+	}
+	return responder.CreateResponse(ctx, params)
+}
+
+// ExtractDSLCode pulls the generated DSL code out of an OpenAI Responses API
+// result. response may be a plain string, or a map[string]any shaped like
+// the Responses API payload ("output_text", or nested "output"/"content").
+// The result is passed through StripMarkdownFences, since CFG grammars
+// reduce the chance of it but don't rule out the model wrapping its answer
+// in a code fence.
+func (p *OpenAICFGProvider) ExtractDSLCode(response interface{}) (string, error) {
+	switch r := response.(type) {
+	case string:
+		return StripMarkdownFences(r), nil
+	case map[string]any:
+		if text, ok := r["output_text"].(string); ok {
+			return StripMarkdownFences(text), nil
+		}
+		if outputs, ok := r["output"].([]any); ok {
+			for _, o := range outputs {
+				item, ok := o.(map[string]any)
+				if !ok {
+					continue
+				}
+				content, ok := item["content"].([]any)
+				if !ok {
+					continue
+				}
+				for _, c := range content {
+					cm, ok := c.(map[string]any)
+					if !ok {
+						continue
+					}
+					if text, ok := cm["text"].(string); ok {
+						return StripMarkdownFences(text), nil
+					}
+				}
+			}
+		}
+		return "", fmt.Errorf("gs: could not find DSL code in OpenAI response")
+	default:
+		return "", fmt.Errorf("gs: unsupported response type %T", response)
+	}
+}
@@ -0,0 +1,118 @@
+package gs
+
+import (
+	"context"
+	"testing"
+)
+
+// sampleOpenAIResponse mimics a Responses API result carrying the DSL code
+// in a nested output/content text item, the shape ExtractDSLCode walks when
+// "output_text" isn't present directly.
+func sampleOpenAIResponse(dslCode string) map[string]any {
+	return map[string]any{
+		"id": "resp_01",
+		"output": []any{
+			map[string]any{
+				"type": "message",
+				"content": []any{
+					map[string]any{
+						"type": "output_text",
+						"text": dslCode,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestOpenAIExtractDSLCodeFromOutputText(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	response := map[string]any{"output_text": `play(track="intro")`}
+	got, err := p.ExtractDSLCode(response)
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestOpenAIExtractDSLCodeFromNestedOutput(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	got, err := p.ExtractDSLCode(sampleOpenAIResponse(`play(track="intro")`))
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestOpenAIExtractDSLCodeStripsFence(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	response := map[string]any{"output_text": "```\nplay(track=\"intro\")\n```"}
+	got, err := p.ExtractDSLCode(response)
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestOpenAIExtractDSLCodeFromPlainString(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	got, err := p.ExtractDSLCode(`play(track="intro")`)
+	if err != nil {
+		t.Fatalf("ExtractDSLCode: %v", err)
+	}
+	if got != `play(track="intro")` {
+		t.Errorf("ExtractDSLCode = %q, want %q", got, `play(track="intro")`)
+	}
+}
+
+func TestOpenAIExtractDSLCodeErrorsWithoutContent(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	if _, err := p.ExtractDSLCode(map[string]any{}); err == nil {
+		t.Error("ExtractDSLCode succeeded on a response with no content, want an error")
+	}
+}
+
+func TestOpenAIExtractDSLCodeErrorsOnUnsupportedType(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	if _, err := p.ExtractDSLCode(42); err == nil {
+		t.Error("ExtractDSLCode succeeded on an unsupported response type, want an error")
+	}
+}
+
+type fakeOpenAIResponder struct {
+	gotParams map[string]any
+	response  map[string]any
+}
+
+func (f *fakeOpenAIResponder) CreateResponse(ctx context.Context, params map[string]any) (map[string]any, error) {
+	f.gotParams = params
+	return f.response, nil
+}
+
+func TestOpenAIGenerateUsesClient(t *testing.T) {
+	client := &fakeOpenAIResponder{response: sampleOpenAIResponse(`play(track="intro")`)}
+	p := &OpenAICFGProvider{}
+	result, err := p.Generate(context.Background(), "play the intro", "gpt-5", nil, nil, client, nil)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if client.gotParams["model"] != "gpt-5" {
+		t.Errorf("model = %v, want %q", client.gotParams["model"], "gpt-5")
+	}
+	if result == nil {
+		t.Error("Generate returned a nil result")
+	}
+}
+
+func TestOpenAIGenerateRejectsIncompatibleClient(t *testing.T) {
+	p := &OpenAICFGProvider{}
+	if _, err := p.Generate(context.Background(), "prompt", "gpt-5", nil, nil, "not a client", nil); err == nil {
+		t.Error("Generate succeeded with an incompatible client, want an error")
+	}
+}
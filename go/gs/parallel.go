@@ -0,0 +1,62 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ExecuteParallel parses code (typically a single chain of otherwise
+// independent calls, e.g. 500 track() calls built to populate a session)
+// and dispatches its calls through a worker pool bounded to maxConcurrency
+// concurrent calls, instead of Execute's one-at-a-time in-order dispatch.
+// Since chaining normally implies order, this is opt-in: only use it when
+// the chain's calls don't depend on each other and their handlers don't
+// share mutable state without their own synchronization. maxConcurrency
+// below 1 is treated as 1.
+//
+// Every worker shares ctx, which is cancelled as soon as any call errors,
+// so handlers that watch ctx stop promptly instead of continuing to do
+// work whose result will be discarded. Errors from every failed call are
+// joined together and returned once all in-flight calls have finished; nil
+// means every call succeeded.
+func (e *Engine) ExecuteParallel(ctx context.Context, code string, maxConcurrency int) error {
+	chain, err := e.parse(code)
+	if err != nil {
+		return fmt.Errorf("gs: parse error: %w", err)
+	}
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var errs []error
+	for _, call := range chain.Calls {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			continue
+		}
+		wg.Add(1)
+		go func(call Call) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := e.executeCall(ctx, call); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+				cancel()
+			}
+		}(call)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
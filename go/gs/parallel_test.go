@@ -0,0 +1,102 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type trackingDSL struct {
+	mu      sync.Mutex
+	running int
+	maxSeen int
+	ran     int32
+}
+
+func (d *trackingDSL) Track(args Args) error {
+	d.mu.Lock()
+	d.running++
+	if d.running > d.maxSeen {
+		d.maxSeen = d.running
+	}
+	d.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+	atomic.AddInt32(&d.ran, 1)
+
+	d.mu.Lock()
+	d.running--
+	d.mu.Unlock()
+	return nil
+}
+
+func chainOf(call string, n int) string {
+	calls := make([]string, n)
+	for i := range calls {
+		calls[i] = call
+	}
+	return strings.Join(calls, ".")
+}
+
+func TestExecuteParallelBoundsConcurrency(t *testing.T) {
+	dsl := &trackingDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.ExecuteParallel(context.Background(), chainOf("track()", 20), 3); err != nil {
+		t.Fatalf("ExecuteParallel: %v", err)
+	}
+	if dsl.ran != 20 {
+		t.Errorf("ran %d calls, want 20", dsl.ran)
+	}
+	if dsl.maxSeen > 3 {
+		t.Errorf("saw %d calls running concurrently, want at most 3", dsl.maxSeen)
+	}
+}
+
+func TestExecuteParallelClampsMaxConcurrencyBelowOne(t *testing.T) {
+	dsl := &trackingDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if err := e.ExecuteParallel(context.Background(), chainOf("track()", 5), 0); err != nil {
+		t.Fatalf("ExecuteParallel: %v", err)
+	}
+	if dsl.ran != 5 {
+		t.Errorf("ran %d calls, want 5", dsl.ran)
+	}
+}
+
+type failingDSL struct {
+	ran int32
+}
+
+func (d *failingDSL) Fail(args Args) error {
+	atomic.AddInt32(&d.ran, 1)
+	return fmt.Errorf("boom")
+}
+
+func TestExecuteParallelAggregatesErrorsAndCancelsOnFirstFailure(t *testing.T) {
+	dsl := &failingDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	total := 20
+	err = e.ExecuteParallel(context.Background(), chainOf("fail()", total), 1)
+	if err == nil {
+		t.Fatal("ExecuteParallel succeeded, want an aggregated error")
+	}
+	if !strings.Contains(err.Error(), "boom") {
+		t.Errorf("error %q does not contain the underlying failure", err.Error())
+	}
+	if ran := atomic.LoadInt32(&dsl.ran); ran >= int32(total) {
+		t.Errorf("ran %d of %d calls, want cancellation to stop well short of running every call", ran, total)
+	}
+}
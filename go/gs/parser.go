@@ -0,0 +1,7 @@
+package gs
+
+// Parser turns DSL source text into a CallChain. Implementations are
+// pluggable so users can bring their own grammar/parser backend.
+type Parser interface {
+	Parse(input string) (*CallChain, error)
+}
@@ -0,0 +1,70 @@
+//go:build participle
+
+// This file is only built with -tags participle, since
+// github.com/alecthomas/participle/v2 is not a default dependency of this
+// module. To use ParticipleParser, run `go get github.com/alecthomas/participle/v2`
+// in go/ first so it is recorded in go.mod/go.sum, then build with that tag.
+
+package gs
+
+import (
+	"fmt"
+
+	"github.com/alecthomas/participle/v2"
+	"github.com/alecthomas/participle/v2/lexer"
+)
+
+// participleCallChain, participleCall, and participleArg mirror CallChain,
+// Call, and Arg with struct tags participle uses to derive its grammar,
+// since participle builds a parser from annotated Go types rather than a
+// grammar string.
+type participleCallChain struct {
+	Calls []*participleCall `parser:"@@ ('.' @@)*"`
+}
+
+type participleCall struct {
+	Name string           `parser:"@Ident"`
+	Args []*participleArg `parser:"'(' (@@ (',' @@)*)? ')'"`
+}
+
+type participleArg struct {
+	Name  string `parser:"(@Ident '=')?"`
+	Value string `parser:"@(Ident | Number | String)"`
+}
+
+var participleLexer = lexer.MustSimple([]lexer.SimpleRule{
+	{Name: "Ident", Pattern: `[A-Za-z_][A-Za-z0-9_]*`},
+	{Name: "Number", Pattern: `[-+]?\d+(\.\d+)?`},
+	{Name: "String", Pattern: `"(\\.|[^"])*"|'(\\.|[^'])*'`},
+	{Name: "Punct", Pattern: `[().,=]`},
+	{Name: "Whitespace", Pattern: `\s+`},
+})
+
+var participleGrammar = participle.MustBuild[participleCallChain](
+	participle.Lexer(participleLexer),
+	participle.Elide("Whitespace"),
+)
+
+// ParticipleParser implements Parser using the participle parser-combinator
+// library instead of the hand-rolled DefaultParser lexer/grammar. It accepts
+// the same call-chain syntax; callers who want participle's richer error
+// messages or grammar composition features can swap it in for DefaultParser
+// without changing anything else about how the Engine is built.
+type ParticipleParser struct{}
+
+// Parse implements Parser.
+func (p *ParticipleParser) Parse(input string) (*CallChain, error) {
+	parsed, err := participleGrammar.ParseString("", input)
+	if err != nil {
+		return nil, fmt.Errorf("gs: participle parse error: %w", err)
+	}
+	chain := &CallChain{Calls: make([]Call, len(parsed.Calls))}
+	for i, c := range parsed.Calls {
+		call := Call{Name: c.Name, Args: make([]Arg, len(c.Args))}
+		for j, a := range c.Args {
+			call.Args[j] = Arg{Name: a.Name, Value: NewIdentifierValue(a.Value)}
+		}
+		chain.Calls[i] = call
+	}
+	return chain, nil
+}
@@ -0,0 +1,51 @@
+//go:build participle
+
+package gs
+
+import "testing"
+
+func TestParticipleParserSingleCall(t *testing.T) {
+	p := &ParticipleParser{}
+	chain, err := p.Parse(`play(track=intro)`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 || chain.Calls[0].Name != "play" {
+		t.Fatalf("Calls = %+v, want a single play() call", chain.Calls)
+	}
+	if len(chain.Calls[0].Args) != 1 || chain.Calls[0].Args[0].Name != "track" {
+		t.Fatalf("Args = %+v, want a single track= arg", chain.Calls[0].Args)
+	}
+}
+
+func TestParticipleParserChainedCalls(t *testing.T) {
+	p := &ParticipleParser{}
+	chain, err := p.Parse(`play(track=intro).stop()`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 2 {
+		t.Fatalf("Calls = %+v, want 2 calls", chain.Calls)
+	}
+	if chain.Calls[0].Name != "play" || chain.Calls[1].Name != "stop" {
+		t.Errorf("Calls = %+v, want [play stop]", chain.Calls)
+	}
+}
+
+func TestParticipleParserPositionalArg(t *testing.T) {
+	p := &ParticipleParser{}
+	chain, err := p.Parse(`play(intro)`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(chain.Calls) != 1 || len(chain.Calls[0].Args) != 1 || chain.Calls[0].Args[0].Name != "" {
+		t.Fatalf("Args = %+v, want a single unnamed positional arg", chain.Calls[0].Args)
+	}
+}
+
+func TestParticipleParserErrorsOnMalformedInput(t *testing.T) {
+	p := &ParticipleParser{}
+	if _, err := p.Parse(`play(`); err == nil {
+		t.Error("Parse succeeded on malformed input, want an error")
+	}
+}
@@ -0,0 +1,67 @@
+package gs
+
+import "strings"
+
+// splitStatements splits DSL source into independent top-level statements
+// on semicolons and newlines, ignoring separators that appear inside string
+// literals or nested parentheses.
+func splitStatements(input string) []string {
+	var statements []string
+	var sb strings.Builder
+	depth := 0
+	var inString rune
+	for _, r := range input {
+		switch {
+		case inString != 0:
+			sb.WriteRune(r)
+			if r == inString {
+				inString = 0
+			}
+		case r == '"' || r == '\'':
+			inString = r
+			sb.WriteRune(r)
+		case r == '(':
+			depth++
+			sb.WriteRune(r)
+		case r == ')':
+			depth--
+			sb.WriteRune(r)
+		case depth == 0 && (r == ';' || r == '\n'):
+			if s := strings.TrimSpace(sb.String()); s != "" {
+				statements = append(statements, s)
+			}
+			sb.Reset()
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	if s := strings.TrimSpace(sb.String()); s != "" {
+		statements = append(statements, s)
+	}
+	return mergeLeadingComments(statements)
+}
+
+// mergeLeadingComments folds a statement that is only a leading comment
+// (e.g. "# priority: high") into the following statement, so it stays
+// attached to the call it annotates (see Call.Meta) instead of being
+// handed to the parser as its own, call-less statement. Consecutive
+// comment-only statements all attach to the same following statement.
+func mergeLeadingComments(statements []string) []string {
+	var merged []string
+	var pending []string
+	for _, s := range statements {
+		if strings.HasPrefix(s, "#") || strings.HasPrefix(s, "//") {
+			pending = append(pending, s)
+			continue
+		}
+		if len(pending) > 0 {
+			s = strings.Join(append(pending, s), "\n")
+			pending = nil
+		}
+		merged = append(merged, s)
+	}
+	if len(pending) > 0 {
+		merged = append(merged, strings.Join(pending, "\n"))
+	}
+	return merged
+}
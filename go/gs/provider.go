@@ -0,0 +1,18 @@
+package gs
+
+import "context"
+
+// CFGProvider integrates Grammar School's CFG utilities with a specific LLM
+// provider's grammar-constrained generation feature, so callers can target
+// different providers through the same API.
+type CFGProvider interface {
+	BuildTool(toolName, description, grammar, syntax string) (map[string]any, error)
+	GetTextFormat() map[string]any
+	Generate(ctx context.Context, prompt, model string, tools []map[string]any, textFormat map[string]any, client interface{}, kwargs map[string]any) (interface{}, error)
+	ExtractDSLCode(response interface{}) (string, error)
+	// ValidateGrammarForSyntax checks grammar against both the generic
+	// structural rules (see ValidateGrammar) and any additional constraints
+	// this provider's grammar-constrained generation feature imposes, such
+	// as which syntax values it natively supports.
+	ValidateGrammarForSyntax(grammar, syntax string) error
+}
@@ -0,0 +1,52 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecordingRuntimeRecordsActions(t *testing.T) {
+	rt := NewRecordingRuntime()
+	a := Action{Kind: "create_track", Payload: map[string]interface{}{"name": "intro"}}
+	if err := rt.ExecuteAction(context.Background(), a); err != nil {
+		t.Fatalf("ExecuteAction: %v", err)
+	}
+	if len(rt.Actions) != 1 || rt.Actions[0].Kind != "create_track" {
+		t.Fatalf("Actions = %+v, want [create_track]", rt.Actions)
+	}
+}
+
+func TestRecordingRuntimeActionsOfKindFilters(t *testing.T) {
+	rt := NewRecordingRuntime()
+	rt.ExecuteAction(context.Background(), Action{Kind: "create_track"})
+	rt.ExecuteAction(context.Background(), Action{Kind: "play"})
+	rt.ExecuteAction(context.Background(), Action{Kind: "create_track"})
+
+	tracks := rt.ActionsOfKind("create_track")
+	if len(tracks) != 2 {
+		t.Fatalf("ActionsOfKind(create_track) = %d actions, want 2", len(tracks))
+	}
+	if plays := rt.ActionsOfKind("play"); len(plays) != 1 {
+		t.Fatalf("ActionsOfKind(play) = %d actions, want 1", len(plays))
+	}
+	if missing := rt.ActionsOfKind("stop"); missing != nil {
+		t.Errorf("ActionsOfKind(stop) = %v, want nil", missing)
+	}
+}
+
+func TestRecordingRuntimeSetErrorAppliesPerKind(t *testing.T) {
+	rt := NewRecordingRuntime()
+	wantErr := errors.New("device unavailable")
+	rt.SetError("play", wantErr)
+
+	if err := rt.ExecuteAction(context.Background(), Action{Kind: "create_track"}); err != nil {
+		t.Errorf("ExecuteAction(create_track) = %v, want nil", err)
+	}
+	if err := rt.ExecuteAction(context.Background(), Action{Kind: "play"}); !errors.Is(err, wantErr) {
+		t.Errorf("ExecuteAction(play) = %v, want %v", err, wantErr)
+	}
+	if len(rt.Actions) != 2 {
+		t.Errorf("Actions recorded = %d, want 2 (both calls still recorded despite the error)", len(rt.Actions))
+	}
+}
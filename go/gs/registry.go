@@ -0,0 +1,55 @@
+package gs
+
+import (
+	"fmt"
+	"sync"
+)
+
+// provider registry names for the built-in CFGProvider implementations.
+const (
+	ProviderOpenAI    = "openai"
+	ProviderAnthropic = "anthropic"
+	ProviderGoogle    = "google"
+)
+
+var (
+	providerMu sync.RWMutex
+	providers  = map[string]CFGProvider{
+		ProviderOpenAI:    &OpenAICFGProvider{},
+		ProviderAnthropic: &AnthropicCFGProvider{},
+		ProviderGoogle:    &GoogleCFGProvider{},
+	}
+)
+
+// RegisterCFGProvider registers p under name, overwriting any provider
+// previously registered under that name. Built-in providers are registered
+// under "openai", "anthropic", and "google". Safe for concurrent use; call
+// MustRegisterCFGProvider instead if overwriting an existing name should be
+// an error.
+func RegisterCFGProvider(name string, p CFGProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	providers[name] = p
+}
+
+// MustRegisterCFGProvider registers p under name like RegisterCFGProvider,
+// but returns an error instead of silently overwriting a provider already
+// registered under that name.
+func MustRegisterCFGProvider(name string, p CFGProvider) error {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	if _, exists := providers[name]; exists {
+		return fmt.Errorf("gs: a CFGProvider is already registered under %q", name)
+	}
+	providers[name] = p
+	return nil
+}
+
+// GetCFGProvider looks up a CFGProvider by the name it was registered
+// under, reporting false if no provider is registered under that name.
+func GetCFGProvider(name string) (CFGProvider, bool) {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	p, ok := providers[name]
+	return p, ok
+}
@@ -0,0 +1,42 @@
+package gs
+
+import "testing"
+
+func TestGetCFGProviderBuiltins(t *testing.T) {
+	for _, name := range []string{ProviderOpenAI, ProviderAnthropic, ProviderGoogle} {
+		if _, ok := GetCFGProvider(name); !ok {
+			t.Errorf("GetCFGProvider(%q) not found, want a pre-registered built-in", name)
+		}
+	}
+}
+
+func TestGetCFGProviderNotFound(t *testing.T) {
+	if p, ok := GetCFGProvider("does-not-exist"); ok || p != nil {
+		t.Errorf("GetCFGProvider(%q) = (%v, %v), want (nil, false)", "does-not-exist", p, ok)
+	}
+}
+
+func TestMustRegisterCFGProviderRejectsDuplicate(t *testing.T) {
+	const name = "test-duplicate-provider"
+	if err := MustRegisterCFGProvider(name, &OpenAICFGProvider{}); err != nil {
+		t.Fatalf("first MustRegisterCFGProvider(%q): %v", name, err)
+	}
+	if err := MustRegisterCFGProvider(name, &OpenAICFGProvider{}); err == nil {
+		t.Errorf("second MustRegisterCFGProvider(%q) succeeded, want an error on duplicate name", name)
+	}
+}
+
+func TestRegisterCFGProviderOverwrites(t *testing.T) {
+	const name = "test-overwrite-provider"
+	first := &OpenAICFGProvider{}
+	second := &AnthropicCFGProvider{}
+	RegisterCFGProvider(name, first)
+	RegisterCFGProvider(name, second)
+	got, ok := GetCFGProvider(name)
+	if !ok {
+		t.Fatalf("GetCFGProvider(%q) not found after registering", name)
+	}
+	if got != CFGProvider(second) {
+		t.Errorf("GetCFGProvider(%q) returned the first registration, want the overwrite to have won", name)
+	}
+}
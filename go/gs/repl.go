@@ -0,0 +1,34 @@
+package gs
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// REPL reads DSL source from in line by line, executes each non-blank line,
+// and writes its result (or error) to out, continuing until in reaches EOF.
+// A line that fails to parse or execute is reported to out rather than
+// stopping the loop, so a typo doesn't end the session. It reuses
+// ExecuteWithResult, so verbs registered as a ResultHandler (including the
+// built-in help verb, see SetHelpEnabled) have their return value printed.
+func (e *Engine) REPL(ctx context.Context, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		results, err := e.ExecuteWithResult(ctx, line)
+		if err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+			continue
+		}
+		for _, result := range results {
+			fmt.Fprintln(out, result.String())
+		}
+	}
+	return scanner.Err()
+}
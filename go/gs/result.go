@@ -0,0 +1,85 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// ResultHandler is like VerbHandler but also returns the Value the method
+// produced, for DSL methods whose result other calls or the caller care
+// about (e.g. a "query" verb), as opposed to ones only run for effect.
+type ResultHandler func(args Args) (Value, error)
+
+var resultHandlerType = reflect.TypeOf((func(Args) (Value, error))(nil))
+
+// discoverResultMethods registers every method of dsl matching
+// func(Args) (Value, error) as a ResultHandler, the same way discoverMethods
+// registers func(Args) error methods as VerbHandlers, including the same
+// collision detection for methods that register the same snake_case name.
+func (e *Engine) discoverResultMethods(dsl interface{}) error {
+	v := reflect.ValueOf(dsl)
+	t := v.Type()
+	e.resultVerbsMu.Lock()
+	defer e.resultVerbsMu.Unlock()
+	claimedBy := make(map[string]string, t.NumMethod())
+	var collisions []string
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		methodValue := v.Method(i)
+		if methodValue.Type() != resultHandlerType {
+			continue
+		}
+		key := e.methodKey(toSnakeCase(m.Name))
+		if first, ok := claimedBy[key]; ok {
+			collisions = append(collisions, fmt.Sprintf("%q (from %s and %s)", key, first, m.Name))
+			continue
+		}
+		claimedBy[key] = m.Name
+		handler := methodValue.Interface().(func(Args) (Value, error))
+		e.resultVerbs[key] = ResultHandler(handler)
+	}
+	collisions = append(collisions, e.ambiguousEmbeddedCollisions(dsl, resultHandlerType)...)
+	return collisionError(collisions)
+}
+
+// RegisterResultMethod registers handler under name as a ResultHandler,
+// overwriting any handler (of either kind) already registered under that
+// name.
+func (e *Engine) RegisterResultMethod(name string, handler ResultHandler) {
+	e.resultVerbsMu.Lock()
+	defer e.resultVerbsMu.Unlock()
+	e.resultVerbs[e.methodKey(name)] = handler
+}
+
+func (e *Engine) resultVerb(name string) (ResultHandler, bool) {
+	e.resultVerbsMu.RLock()
+	defer e.resultVerbsMu.RUnlock()
+	handler, ok := e.resultVerbs[e.methodKey(name)]
+	return handler, ok
+}
+
+// ExecuteWithResult behaves like Execute but also returns the Value
+// produced by every call across every statement in code, in order. Calls
+// resolved to a plain VerbHandler (one registered with RegisterMethod or
+// discovered via NewEngine's func(Args) error reflection) contribute the
+// zero Value, since they have nothing to report; only methods registered as
+// a ResultHandler (see RegisterResultMethod) or discovered via
+// func(Args) (Value, error) reflection produce a real result.
+func (e *Engine) ExecuteWithResult(ctx context.Context, code string) ([]Value, error) {
+	var results []Value
+	for _, statement := range splitStatements(code) {
+		chain, err := e.parse(statement)
+		if err != nil {
+			return results, fmt.Errorf("gs: parse error: %w", err)
+		}
+		for _, call := range chain.Calls {
+			result, err := e.executeCallWithResult(ctx, call)
+			if err != nil {
+				return results, err
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,80 @@
+package gs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// transientErr is an error that reports itself as safe to retry.
+type transientErr struct{ msg string }
+
+func (e *transientErr) Error() string   { return e.msg }
+func (e *transientErr) Transient() bool { return true }
+
+type flakyDSL struct {
+	attempts int
+}
+
+func (d *flakyDSL) Fetch(args Args) error {
+	d.attempts++
+	if d.attempts < 3 {
+		return &transientErr{msg: "temporary outage"}
+	}
+	return nil
+}
+
+func TestRetryPolicySucceedsAfterTransientFailures(t *testing.T) {
+	dsl := &flakyDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetRetryPolicy(5, time.Millisecond)
+	if err := e.Execute(context.Background(), "fetch()"); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if dsl.attempts != 3 {
+		t.Errorf("attempts = %d, want 3 (two failures then a success)", dsl.attempts)
+	}
+}
+
+func TestRetryPolicyGivesUpAfterMaxAttempts(t *testing.T) {
+	dsl := &flakyDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetRetryPolicy(2, time.Millisecond)
+	if err := e.Execute(context.Background(), "fetch()"); err == nil {
+		t.Fatal("Execute succeeded, want the second transient failure to exhaust retries")
+	}
+	if dsl.attempts != 2 {
+		t.Errorf("attempts = %d, want 2 (capped by MaxAttempts)", dsl.attempts)
+	}
+}
+
+type permanentFailDSL struct {
+	attempts int
+}
+
+func (d *permanentFailDSL) Fetch(args Args) error {
+	d.attempts++
+	return errors.New("not transient")
+}
+
+func TestRetryPolicyDoesNotRetryNonTransientErrors(t *testing.T) {
+	dsl := &permanentFailDSL{}
+	e, err := NewEngine("", dsl, &DefaultParser{})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	e.SetRetryPolicy(5, time.Millisecond)
+	if err := e.Execute(context.Background(), "fetch()"); err == nil {
+		t.Fatal("Execute succeeded, want the non-transient error to surface")
+	}
+	if dsl.attempts != 1 {
+		t.Errorf("attempts = %d, want 1 (a non-transient error must not be retried)", dsl.attempts)
+	}
+}
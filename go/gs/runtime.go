@@ -0,0 +1,97 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Runtime executes Actions produced by the interpreter. It is part of the
+// internal two-layer architecture; most users do not need to implement it
+// since method handlers execute directly.
+type Runtime interface {
+	ExecuteAction(ctx context.Context, a Action) error
+}
+
+// DefaultRuntime is a basic Runtime that records every Action it executes
+// by writing a line describing it to an io.Writer. It exists mainly for
+// debugging the internal two-layer architecture path; most callers execute
+// DSL methods directly via Engine instead of going through a Runtime.
+type DefaultRuntime struct {
+	w io.Writer
+}
+
+// NewDefaultRuntime returns a DefaultRuntime that writes to w. A nil w
+// defaults to os.Stdout.
+func NewDefaultRuntime(w io.Writer) *DefaultRuntime {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &DefaultRuntime{w: w}
+}
+
+// ExecuteAction implements Runtime by writing a line describing a to the
+// runtime's writer. A zero-value DefaultRuntime (constructed as
+// DefaultRuntime{} rather than via NewDefaultRuntime) still defaults to
+// os.Stdout.
+func (r *DefaultRuntime) ExecuteAction(ctx context.Context, a Action) error {
+	w := r.w
+	if w == nil {
+		w = os.Stdout
+	}
+	_, err := fmt.Fprintf(w, "%s %v\n", a.Kind, a.Payload)
+	return err
+}
+
+// RecordingRuntime is a Runtime that stores every Action it receives in
+// Actions instead of executing it, so a test can assert on exactly what an
+// Action-returning DSL produced without capturing stdout or faking a real
+// backend. It is safe for concurrent use.
+type RecordingRuntime struct {
+	mu      sync.Mutex
+	Actions []Action
+	errors  map[string]error
+}
+
+// NewRecordingRuntime returns a RecordingRuntime with no recorded Actions
+// and no configured errors.
+func NewRecordingRuntime() *RecordingRuntime {
+	return &RecordingRuntime{}
+}
+
+// ExecuteAction implements Runtime by appending a to rt.Actions and
+// returning the error configured for a.Kind via SetError, if any.
+func (rt *RecordingRuntime) ExecuteAction(ctx context.Context, a Action) error {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.Actions = append(rt.Actions, a)
+	return rt.errors[a.Kind]
+}
+
+// SetError makes ExecuteAction return err for every subsequent Action whose
+// Kind is kind, so a test can exercise a DSL's handling of a failing
+// runtime without needing a real backend that can fail on demand.
+func (rt *RecordingRuntime) SetError(kind string, err error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if rt.errors == nil {
+		rt.errors = make(map[string]error)
+	}
+	rt.errors[kind] = err
+}
+
+// ActionsOfKind returns every recorded Action whose Kind is kind, in the
+// order ExecuteAction received them.
+func (rt *RecordingRuntime) ActionsOfKind(kind string) []Action {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	var matches []Action
+	for _, a := range rt.Actions {
+		if a.Kind == kind {
+			matches = append(matches, a)
+		}
+	}
+	return matches
+}
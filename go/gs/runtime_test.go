@@ -0,0 +1,26 @@
+package gs
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewDefaultRuntimeWritesToProvidedWriter(t *testing.T) {
+	var buf bytes.Buffer
+	rt := NewDefaultRuntime(&buf)
+	if err := rt.ExecuteAction(context.Background(), Action{Kind: "create_track", Payload: map[string]interface{}{"name": "intro"}}); err != nil {
+		t.Fatalf("ExecuteAction: %v", err)
+	}
+	if got := buf.String(); !strings.Contains(got, "create_track") || !strings.Contains(got, "intro") {
+		t.Errorf("buffer = %q, want it to mention the action's kind and payload", got)
+	}
+}
+
+func TestDefaultRuntimeZeroValueStillWorks(t *testing.T) {
+	rt := DefaultRuntime{}
+	if err := rt.ExecuteAction(context.Background(), Action{Kind: "create_track"}); err != nil {
+		t.Fatalf("ExecuteAction on a zero-value DefaultRuntime: %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package gs
+
+import "strings"
+
+// StreamingParser is an optional extension of Parser for incremental input:
+// callers feed chunks as they arrive (e.g. tokens streamed from an LLM) and
+// ask whether a complete statement is buffered and ready to parse.
+type StreamingParser interface {
+	Parser
+	// Feed appends chunk to the internal buffer and reports whether the
+	// buffer now holds a complete, balanced statement.
+	Feed(chunk string) (ready bool, err error)
+	// Reset discards any buffered input.
+	Reset()
+}
+
+// StreamingDefaultParser adapts DefaultParser to the StreamingParser
+// interface by tracking paren depth and quote state across chunks.
+type StreamingDefaultParser struct {
+	DefaultParser
+	buf      strings.Builder
+	depth    int
+	inString rune
+}
+
+// Feed implements StreamingParser.
+func (p *StreamingDefaultParser) Feed(chunk string) (bool, error) {
+	for _, r := range chunk {
+		p.buf.WriteRune(r)
+		switch {
+		case p.inString != 0:
+			if r == p.inString {
+				p.inString = 0
+			}
+		case r == '"' || r == '\'':
+			p.inString = r
+		case r == '(':
+			p.depth++
+		case r == ')':
+			p.depth--
+		}
+	}
+	ready := p.depth <= 0 && p.inString == 0 && strings.TrimSpace(p.buf.String()) != ""
+	return ready, nil
+}
+
+// Reset implements StreamingParser.
+func (p *StreamingDefaultParser) Reset() {
+	p.buf.Reset()
+	p.depth = 0
+	p.inString = 0
+}
+
+// Parse parses input when it is non-empty, and otherwise whatever has been
+// buffered via Feed so far.
+func (p *StreamingDefaultParser) Parse(input string) (*CallChain, error) {
+	if input != "" {
+		return p.DefaultParser.Parse(input)
+	}
+	return p.DefaultParser.Parse(p.buf.String())
+}
@@ -0,0 +1,52 @@
+package gs
+
+import (
+	"context"
+	"fmt"
+)
+
+// CallResult is one call's outcome as it completes, as emitted by
+// StreamResults. Value is the zero Value for calls resolved to a plain
+// VerbHandler (see ExecuteWithResult), since those have nothing to report.
+type CallResult struct {
+	Method string
+	Value  Value
+}
+
+// StreamResults parses and executes code exactly like ExecuteWithResult,
+// but reports each call's CallResult on the returned channel as soon as it
+// completes instead of collecting them into a slice, so a UI can render
+// progressive output (e.g. each created track appearing immediately) rather
+// than waiting for the whole program to finish. Both channels close once
+// every statement has run or a parse or handler error stops execution; the
+// error channel receives at most one error, since gs stops at the first
+// failing statement just as ExecuteWithResult does.
+func (e *Engine) StreamResults(ctx context.Context, code string) (<-chan CallResult, <-chan error) {
+	results := make(chan CallResult)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(results)
+		defer close(errs)
+		for _, statement := range splitStatements(code) {
+			chain, err := e.parse(statement)
+			if err != nil {
+				errs <- fmt.Errorf("gs: parse error: %w", err)
+				return
+			}
+			for _, call := range chain.Calls {
+				value, err := e.executeCallWithResult(ctx, call)
+				if err != nil {
+					errs <- err
+					return
+				}
+				select {
+				case results <- CallResult{Method: call.Name, Value: value}:
+				case <-ctx.Done():
+					errs <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return results, errs
+}
@@ -0,0 +1,60 @@
+package gs
+
+import "testing"
+
+func TestLexStringEscapes(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"quote", `"say \"hi\""`, `say "hi"`},
+		{"backslash", `"a\\b"`, `a\b`},
+		{"newline", `"a\nb"`, "a\nb"},
+		{"tab", `"a\tb"`, "a\tb"},
+		{"unicode escape", `"\u00e9"`, "é"},
+		{"astral surrogate pair", `"\ud83d\ude00"`, "😀"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, n, err := lexString([]rune(tc.in))
+			if err != nil {
+				t.Fatalf("lexString(%q) returned error: %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Errorf("lexString(%q) = %q, want %q", tc.in, got, tc.want)
+			}
+			if n != len([]rune(tc.in)) {
+				t.Errorf("lexString(%q) consumed %d runes, want %d", tc.in, n, len([]rune(tc.in)))
+			}
+		})
+	}
+}
+
+func TestLexStringUnpairedSurrogate(t *testing.T) {
+	if _, _, err := lexString([]rune(`"\ud83dx"`)); err == nil {
+		t.Error("lexString with an unpaired surrogate succeeded, want an error")
+	}
+}
+
+// TestUnicodeEscapeRoundTrip checks that a string containing a non-ASCII
+// character parsed from a \u escape re-renders, via Call.String(), as DSL
+// source that parses back to the same value - Value.String() relies on
+// strconv.Quote, which leaves printable non-ASCII runes as literal UTF-8
+// rather than re-escaping them, and DefaultParser accepts literal UTF-8
+// inside a quoted string, so the round trip holds without lexString or
+// Value.String() needing to agree on any particular escaping.
+func TestUnicodeEscapeRoundTrip(t *testing.T) {
+	chain, err := (&DefaultParser{}).Parse(`greet(name="caf\` + `u00e9")`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	rendered := chain.Calls[0].String()
+	reparsed, err := (&DefaultParser{}).Parse(rendered)
+	if err != nil {
+		t.Fatalf("re-parsing rendered call %q: %v", rendered, err)
+	}
+	if !reparsed.Calls[0].Equal(chain.Calls[0]) {
+		t.Errorf("round trip through %q produced a different call: got %+v, want %+v", rendered, reparsed.Calls[0], chain.Calls[0])
+	}
+}
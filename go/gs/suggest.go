@@ -0,0 +1,67 @@
+package gs
+
+// suggestMethod returns the registered verb name closest to name by edit
+// distance, for use in "did you mean" style error messages. It returns ""
+// if verbs is empty or nothing is close enough to be a plausible typo.
+func suggestMethod(name string, verbs map[string]VerbHandler) string {
+	best := ""
+	bestDist := -1
+	for candidate := range verbs {
+		dist := levenshtein(name, candidate)
+		if bestDist == -1 || dist < bestDist {
+			best = candidate
+			bestDist = dist
+		}
+	}
+	// Only suggest when the typo is small relative to the word length.
+	if best == "" || bestDist > maxInt(2, len(name)/2) {
+		return ""
+	}
+	return best
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows, cols := len(ra)+1, len(rb)+1
+	dist := make([][]int, rows)
+	for i := range dist {
+		dist[i] = make([]int, cols)
+		dist[i][0] = i
+	}
+	for j := 0; j < cols; j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			dist[i][j] = minInt3(
+				dist[i-1][j]+1,
+				dist[i][j-1]+1,
+				dist[i-1][j-1]+cost,
+			)
+		}
+	}
+	return dist[rows-1][cols-1]
+}
+
+func minInt3(a, b, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
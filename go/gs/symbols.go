@@ -0,0 +1,61 @@
+package gs
+
+import "sync"
+
+// SymbolTable maps identifier names to resolved Values, letting DSL
+// programs reference earlier results by name (e.g. `track(name="Drums")`
+// bound to `t`, then `add_clip(track=t)`).
+type SymbolTable struct {
+	mu      sync.RWMutex
+	symbols map[string]Value
+}
+
+// NewSymbolTable returns an empty SymbolTable.
+func NewSymbolTable() *SymbolTable {
+	return &SymbolTable{symbols: make(map[string]Value)}
+}
+
+// Set binds name to value.
+func (s *SymbolTable) Set(name string, value Value) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.symbols[name] = value
+}
+
+// Get returns the Value bound to name, if any.
+func (s *SymbolTable) Get(name string) (Value, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	v, ok := s.symbols[name]
+	return v, ok
+}
+
+// SetSymbolTable attaches a SymbolTable to the Engine. Identifier-kind
+// arguments are resolved against it before dispatch; identifiers with no
+// binding are passed through unresolved so handlers can still treat them as
+// bare enum-like tokens.
+func (e *Engine) SetSymbolTable(symbols *SymbolTable) {
+	e.symbols = symbols
+}
+
+func (e *Engine) resolveValue(v Value) Value {
+	if e.symbols == nil {
+		return v
+	}
+	switch v.Kind {
+	case ValueIdentifier:
+		if bound, ok := e.symbols.Get(v.Str); ok {
+			return bound
+		}
+	case ValueCall:
+		if v.Call != nil {
+			resolved := *v.Call
+			resolved.Args = make([]Arg, len(v.Call.Args))
+			for i, a := range v.Call.Args {
+				resolved.Args[i] = Arg{Name: a.Name, Value: e.resolveValue(a.Value)}
+			}
+			v.Call = &resolved
+		}
+	}
+	return v
+}
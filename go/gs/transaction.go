@@ -0,0 +1,30 @@
+package gs
+
+import "context"
+
+// Snapshotter is an optional interface a DSL can implement to support
+// automatic rollback. Snapshot captures enough of the DSL's internal state
+// to undo everything a CallChain might do to it, and Restore resets the DSL
+// back to a state previously captured by Snapshot.
+type Snapshotter interface {
+	Snapshot() interface{}
+	Restore(snapshot interface{})
+}
+
+// ExecuteTransactional behaves like Execute, but if any call in code fails
+// and the DSL implements Snapshotter, the DSL is restored to the state it
+// was in before code started running, so a failed program leaves no partial
+// effects behind. Without Snapshotter support it behaves exactly like
+// Execute: calls already made are not undone.
+func (e *Engine) ExecuteTransactional(ctx context.Context, code string) error {
+	snapshotter, ok := e.dsl.(Snapshotter)
+	if !ok {
+		return e.Execute(ctx, code)
+	}
+	snapshot := snapshotter.Snapshot()
+	if err := e.Execute(ctx, code); err != nil {
+		snapshotter.Restore(snapshot)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+// Package gs implements the Go runtime for Grammar School DSLs: a parser
+// front end, a reflection-based method dispatcher, and utilities for using
+// a grammar as an LLM CFG constraint.
+package gs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueKind identifies the kind of value held by a Value node.
+type ValueKind int
+
+const (
+	ValueNumber ValueKind = iota
+	ValueString
+	ValueIdentifier
+	ValueBool
+	ValueNull
+	ValueCall
+	ValueList
+)
+
+// Value represents a single value in the parsed AST.
+type Value struct {
+	Kind ValueKind
+	Num  float64
+	// IsFloat reports whether a ValueNumber was written with a decimal
+	// point or an exponent (e.g. "3.0" or "1e3") as opposed to an integer
+	// literal (e.g. "3").
+	IsFloat bool
+	// Unit holds a ValueNumber's unit suffix (e.g. "ms" in "250ms", "bars"
+	// in "8bars"), when the parser was configured to recognize them (see
+	// DefaultParser.AllowUnitSuffixes). It is empty for a plain number.
+	Unit    string
+	Str     string
+	Bool    bool
+	// Call holds the nested call when Kind is ValueCall, e.g. the
+	// `point(x=1, y=2)` in `draw(at=point(x=1, y=2))`.
+	Call *Call
+	// List holds the elements when Kind is ValueList, e.g. the three
+	// ValueNumbers in `[1, 2, 3]`.
+	List []Value
+}
+
+// NewStringValue returns a ValueString Value.
+func NewStringValue(s string) Value {
+	return Value{Kind: ValueString, Str: s}
+}
+
+// NewNumberValue returns an integer-formatted ValueNumber Value.
+func NewNumberValue(n float64) Value {
+	return Value{Kind: ValueNumber, Num: n}
+}
+
+// NewFloatValue returns a float-formatted ValueNumber Value.
+func NewFloatValue(n float64) Value {
+	return Value{Kind: ValueNumber, Num: n, IsFloat: true}
+}
+
+// NewBoolValue returns a ValueBool Value.
+func NewBoolValue(b bool) Value {
+	return Value{Kind: ValueBool, Bool: b}
+}
+
+// NewIdentifierValue returns a ValueIdentifier Value.
+func NewIdentifierValue(name string) Value {
+	return Value{Kind: ValueIdentifier, Str: name}
+}
+
+// NewNullValue returns a ValueNull Value.
+func NewNullValue() Value {
+	return Value{Kind: ValueNull}
+}
+
+// NewCallValue returns a ValueCall Value wrapping call.
+func NewCallValue(call Call) Value {
+	return Value{Kind: ValueCall, Call: &call}
+}
+
+// NewListValue returns a ValueList Value wrapping elems.
+func NewListValue(elems []Value) Value {
+	return Value{Kind: ValueList, List: elems}
+}
+
+// Equal reports whether v and other represent the same value, comparing
+// nested calls recursively.
+func (v Value) Equal(other Value) bool {
+	if v.Kind != other.Kind {
+		return false
+	}
+	switch v.Kind {
+	case ValueNumber:
+		return v.Num == other.Num && v.IsFloat == other.IsFloat && v.Unit == other.Unit
+	case ValueString, ValueIdentifier:
+		return v.Str == other.Str
+	case ValueBool:
+		return v.Bool == other.Bool
+	case ValueNull:
+		return true
+	case ValueCall:
+		if v.Call == nil || other.Call == nil {
+			return v.Call == other.Call
+		}
+		return v.Call.Equal(*other.Call)
+	case ValueList:
+		if len(v.List) != len(other.List) {
+			return false
+		}
+		for i, elem := range v.List {
+			if !elem.Equal(other.List[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// String renders v the way it would appear in DSL source, e.g. 42, 3.14,
+// "hello", true, null, or a nested call like point(x=1, y=2).
+func (v Value) String() string {
+	switch v.Kind {
+	case ValueNumber:
+		var s string
+		if v.IsFloat {
+			s = strconv.FormatFloat(v.Num, 'f', -1, 64)
+		} else {
+			s = strconv.FormatFloat(v.Num, 'f', 0, 64)
+		}
+		return s + v.Unit
+	case ValueString:
+		return strconv.Quote(v.Str)
+	case ValueIdentifier:
+		return v.Str
+	case ValueBool:
+		return strconv.FormatBool(v.Bool)
+	case ValueNull:
+		return "null"
+	case ValueCall:
+		if v.Call == nil {
+			return "null"
+		}
+		return v.Call.String()
+	case ValueList:
+		elems := make([]string, len(v.List))
+		for i, elem := range v.List {
+			elems[i] = elem.String()
+		}
+		return fmt.Sprintf("[%s]", strings.Join(elems, ", "))
+	default:
+		return fmt.Sprintf("<invalid value kind %d>", v.Kind)
+	}
+}
+
+// timeUnits maps a ValueNumber's recognized time-unit suffix (see
+// DefaultParser.AllowUnitSuffixes) to the time.Duration one unit of it
+// represents.
+var timeUnits = map[string]time.Duration{
+	"ms":      time.Millisecond,
+	"s":       time.Second,
+	"sec":     time.Second,
+	"secs":    time.Second,
+	"second":  time.Second,
+	"seconds": time.Second,
+	"m":       time.Minute,
+	"min":     time.Minute,
+	"mins":    time.Minute,
+	"minute":  time.Minute,
+	"minutes": time.Minute,
+	"h":       time.Hour,
+	"hr":      time.Hour,
+	"hrs":     time.Hour,
+	"hour":    time.Hour,
+	"hours":   time.Hour,
+}
+
+// AsDuration converts a ValueNumber carrying a time-unit suffix (e.g.
+// "250ms", "2.5hours") into a time.Duration. It errors if v is not a
+// ValueNumber, has no unit, or its unit isn't a recognized time unit (e.g.
+// "8bars", whose unit is musical rather than temporal).
+func (v Value) AsDuration() (time.Duration, error) {
+	if v.Kind != ValueNumber {
+		return 0, fmt.Errorf("gs: %s is not a number", v.String())
+	}
+	if v.Unit == "" {
+		return 0, fmt.Errorf("gs: %s has no unit", v.String())
+	}
+	unit, ok := timeUnits[v.Unit]
+	if !ok {
+		return 0, fmt.Errorf("gs: %q is not a time unit", v.Unit)
+	}
+	return time.Duration(v.Num * float64(unit)), nil
+}
+
+// AsPercent converts a ValueNumber carrying the "%" unit suffix (e.g.
+// "80%", see DefaultParser.AllowUnitSuffixes) into its fractional form
+// (0.8). The raw written number (80) is always available unconverted via
+// Num, for callers that want to display it back to a user.
+func (v Value) AsPercent() (float64, error) {
+	if v.Kind != ValueNumber {
+		return 0, fmt.Errorf("gs: %s is not a number", v.String())
+	}
+	if v.Unit != "%" {
+		return 0, fmt.Errorf("gs: %s is not a percentage", v.String())
+	}
+	return v.Num / 100, nil
+}
+
+// Interface converts v to a native Go value: float64 for ValueNumber,
+// string for ValueString and ValueIdentifier, bool for ValueBool, nil for
+// ValueNull, []interface{} for ValueList (each element converted
+// recursively), and map[string]interface{} for ValueCall (the call's name
+// under "name", its arguments under "args" keyed the same way
+// Args.ToPayload would). It is the inverse of the New*Value constructors,
+// for handlers that forward args into generic APIs expecting plain Go
+// values (JSON payloads, maps) instead of the Value AST.
+func (v Value) Interface() interface{} {
+	switch v.Kind {
+	case ValueNumber:
+		return v.Num
+	case ValueString, ValueIdentifier:
+		return v.Str
+	case ValueBool:
+		return v.Bool
+	case ValueNull:
+		return nil
+	case ValueList:
+		elems := make([]interface{}, len(v.List))
+		for i, elem := range v.List {
+			elems[i] = elem.Interface()
+		}
+		return elems
+	case ValueCall:
+		if v.Call == nil {
+			return nil
+		}
+		args := make(map[string]interface{}, len(v.Call.Args))
+		pos := 0
+		for _, a := range v.Call.Args {
+			name := a.Name
+			if name == "" {
+				name = PositionalArgName(pos)
+				pos++
+			}
+			args[name] = a.Value.Interface()
+		}
+		return map[string]interface{}{"name": v.Call.Name, "args": args}
+	default:
+		return nil
+	}
+}
+
+// Arg is a named argument passed to a Call.
+type Arg struct {
+	Name  string
+	Value Value
+	// Spread marks a positional argument written as *list, e.g. the
+	// `*coords` in `point(*coords)`. Value must resolve to a ValueList, and
+	// the Engine expands its elements into individual positional arguments
+	// at dispatch time rather than passing the list itself. Spread args are
+	// always positional; Name is empty.
+	Spread bool
+}
+
+// Equal reports whether a and other have the same name, spread flag, and an
+// equal value.
+func (a Arg) Equal(other Arg) bool {
+	return a.Name == other.Name && a.Spread == other.Spread && a.Value.Equal(other.Value)
+}
+
+// String renders a the way it would appear in DSL source, e.g. x=1 for a
+// named argument, 1 for a positional one, or *coords for a spread argument.
+func (a Arg) String() string {
+	if a.Spread {
+		return fmt.Sprintf("*%s", a.Value.String())
+	}
+	if a.Name == "" {
+		return a.Value.String()
+	}
+	return fmt.Sprintf("%s=%s", a.Name, a.Value.String())
+}
+
+// Call represents a single function call with named arguments.
+type Call struct {
+	Name string
+	Args []Arg
+	// Meta carries structured annotations parsed from a "key: value"
+	// leading comment immediately before the call in source (e.g.
+	// "# priority: high\ntrack(...)" sets Meta["priority"] = "high"). It
+	// lets an LLM attach hints for handlers or hooks to read without
+	// inventing a reserved argument name for them. Meta is nil when the
+	// call had no leading comment, or the comment had no "key: value"
+	// lines.
+	Meta map[string]string
+}
+
+// Equal reports whether c and other have the same name and equal,
+// identically-ordered arguments. Meta is not compared, since it is an
+// annotation on the call site rather than part of the call's value.
+func (c Call) Equal(other Call) bool {
+	if c.Name != other.Name || len(c.Args) != len(other.Args) {
+		return false
+	}
+	for i, a := range c.Args {
+		if !a.Equal(other.Args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// String renders c the way it would appear in DSL source, e.g.
+// point(x=1, y=2).
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, a := range c.Args {
+		args[i] = a.String()
+	}
+	return fmt.Sprintf("%s(%s)", c.Name, strings.Join(args, ", "))
+}
+
+// CallChain is a sequence of calls connected by dots (method chaining).
+type CallChain struct {
+	Calls []Call
+	// Version is the string declared by a leading version("1.2") pragma
+	// (see DefaultParser), e.g. "1.2". It is empty when the statement had
+	// no version pragma. Engine.RequireGrammarVersion checks it against a
+	// constraint before executing the chain; absent a constraint, it is
+	// parsed but otherwise unused.
+	Version string
+}
+
+// Args is the map of argument name to Value passed to a MethodHandler.
+// Positional (unnamed) arguments have no name of their own, so the Engine
+// stores each one under the reserved key PositionalArgName(i) for its index
+// i among the call's positional arguments, in source order.
+//
+// Warning: for throughput, the Engine draws Args maps from an internal pool
+// and clears and reuses them for later calls once a handler returns. A
+// handler must not retain the Args map it is given past the end of its own
+// call (e.g. stashing it in a field or sending it on a channel); copy out
+// any values it needs to keep instead.
+type Args map[string]Value
+
+// PositionalArgName returns the reserved Args key a positional argument at
+// index i (0-based, counting only a call's positional arguments) is stored
+// under. Method handlers that accept positional arguments read them back
+// via this key, e.g. args[gs.PositionalArgName(0)] for the first one.
+func PositionalArgName(i int) string {
+	return fmt.Sprintf("_%d", i)
+}
+
+// Primary returns the value of a's sole positional argument, for DSLs that
+// read naturally with one implicit "main" value, e.g. treating the "red" in
+// `set_color(red, fade=true)` as the call's primary value without the
+// handler needing to know it happens to be positional index 0. ok is false
+// when a has zero or more than one positional argument, since there is no
+// single value to return.
+func (a Args) Primary() (Value, bool) {
+	var primary Value
+	count := 0
+	for i := 0; ; i++ {
+		v, ok := a[PositionalArgName(i)]
+		if !ok {
+			break
+		}
+		primary = v
+		count++
+	}
+	if count != 1 {
+		return Value{}, false
+	}
+	return primary, true
+}
+
+// MethodHandler is the signature method handlers must implement. The Engine
+// discovers methods matching this signature via reflection and registers
+// them automatically. Methods execute directly - no Action return needed.
+type MethodHandler func(args Args) error
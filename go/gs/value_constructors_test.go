@@ -0,0 +1,30 @@
+package gs
+
+import "testing"
+
+func TestValueConstructors(t *testing.T) {
+	if v := NewStringValue("x"); v.Kind != ValueString || v.Str != "x" {
+		t.Errorf("NewStringValue = %+v", v)
+	}
+	if v := NewNumberValue(3); v.Kind != ValueNumber || v.Num != 3 || v.IsFloat {
+		t.Errorf("NewNumberValue = %+v", v)
+	}
+	if v := NewFloatValue(3.5); v.Kind != ValueNumber || v.Num != 3.5 || !v.IsFloat {
+		t.Errorf("NewFloatValue = %+v", v)
+	}
+	if v := NewBoolValue(true); v.Kind != ValueBool || !v.Bool {
+		t.Errorf("NewBoolValue = %+v", v)
+	}
+	if v := NewIdentifierValue("x"); v.Kind != ValueIdentifier || v.Str != "x" {
+		t.Errorf("NewIdentifierValue = %+v", v)
+	}
+	if v := NewNullValue(); v.Kind != ValueNull {
+		t.Errorf("NewNullValue = %+v", v)
+	}
+	if v := NewCallValue(Call{Name: "point"}); v.Kind != ValueCall || v.Call == nil || v.Call.Name != "point" {
+		t.Errorf("NewCallValue = %+v", v)
+	}
+	if v := NewListValue([]Value{NewNumberValue(1)}); v.Kind != ValueList || len(v.List) != 1 {
+		t.Errorf("NewListValue = %+v", v)
+	}
+}
@@ -0,0 +1,52 @@
+package gs
+
+import "testing"
+
+func TestValueEqualComparesKindAndPayload(t *testing.T) {
+	cases := []struct {
+		name  string
+		a, b  Value
+		equal bool
+	}{
+		{"equal numbers", NewNumberValue(3), NewNumberValue(3), true},
+		{"different numbers", NewNumberValue(3), NewNumberValue(4), false},
+		{"int vs float same value but different IsFloat", NewNumberValue(3), NewFloatValue(3), false},
+		{"equal strings", NewStringValue("x"), NewStringValue("x"), true},
+		{"different strings", NewStringValue("x"), NewStringValue("y"), false},
+		{"equal bools", NewBoolValue(true), NewBoolValue(true), true},
+		{"different bools", NewBoolValue(true), NewBoolValue(false), false},
+		{"different kinds", NewStringValue("3"), NewNumberValue(3), false},
+		{"equal nulls", NewNullValue(), NewNullValue(), true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.a.Equal(c.b); got != c.equal {
+				t.Errorf("%#v.Equal(%#v) = %v, want %v", c.a, c.b, got, c.equal)
+			}
+		})
+	}
+}
+
+func TestValueEqualComparesListsRecursively(t *testing.T) {
+	a := NewListValue([]Value{NewNumberValue(1), NewStringValue("x")})
+	b := NewListValue([]Value{NewNumberValue(1), NewStringValue("x")})
+	c := NewListValue([]Value{NewNumberValue(1), NewStringValue("y")})
+	if !a.Equal(b) {
+		t.Error("identical lists are not Equal")
+	}
+	if a.Equal(c) {
+		t.Error("lists differing in an element are Equal")
+	}
+}
+
+func TestValueEqualComparesCallsRecursively(t *testing.T) {
+	a := NewCallValue(Call{Name: "point", Args: []Arg{{Name: "x", Value: NewNumberValue(1)}}})
+	b := NewCallValue(Call{Name: "point", Args: []Arg{{Name: "x", Value: NewNumberValue(1)}}})
+	c := NewCallValue(Call{Name: "point", Args: []Arg{{Name: "x", Value: NewNumberValue(2)}}})
+	if !a.Equal(b) {
+		t.Error("identical nested calls are not Equal")
+	}
+	if a.Equal(c) {
+		t.Error("nested calls differing in an argument are Equal")
+	}
+}
@@ -0,0 +1,114 @@
+package gs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed "major.minor.patch" version, as declared by a
+// version("1.2") pragma (see DefaultParser.Parse) or passed to
+// Engine.RequireGrammarVersion. A component omitted from the source string
+// (e.g. "1.2" has no patch) is treated as 0.
+type semver struct {
+	major, minor, patch int
+}
+
+func parseSemver(s string) (semver, error) {
+	parts := strings.SplitN(strings.TrimSpace(s), ".", 3)
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return semver{}, fmt.Errorf("gs: invalid version %q", s)
+		}
+		nums[i] = n
+	}
+	var v semver
+	switch len(nums) {
+	case 1:
+		v.major = nums[0]
+	case 2:
+		v.major, v.minor = nums[0], nums[1]
+	case 3:
+		v.major, v.minor, v.patch = nums[0], nums[1], nums[2]
+	}
+	return v, nil
+}
+
+// compare returns -1, 0, or 1 as v is less than, equal to, or greater than
+// other, comparing major, then minor, then patch.
+func (v semver) compare(other semver) int {
+	if v.major != other.major {
+		return signOf(v.major - other.major)
+	}
+	if v.minor != other.minor {
+		return signOf(v.minor - other.minor)
+	}
+	return signOf(v.patch - other.patch)
+}
+
+func signOf(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// versionConstraintOps lists recognized comparison prefixes for a version
+// constraint, longest first so ">=" isn't parsed as ">" followed by a
+// leftover "=".
+var versionConstraintOps = []string{">=", "<=", "==", ">", "<", "="}
+
+// matchesVersionConstraint reports whether declared (e.g. "1.2", from a
+// version(...) pragma) satisfies constraint (e.g. ">=1.0", "1.2", "^1.2" -
+// the default comparison operator is "=" when none is given). "^1.2" is
+// accepted as shorthand for ">=1.2,<2.0" (same major version, at least the
+// given minor).
+func matchesVersionConstraint(declared, constraint string) (bool, error) {
+	constraint = strings.TrimSpace(constraint)
+	if strings.HasPrefix(constraint, "^") {
+		want, err := parseSemver(constraint[1:])
+		if err != nil {
+			return false, err
+		}
+		have, err := parseSemver(declared)
+		if err != nil {
+			return false, err
+		}
+		return have.major == want.major && have.compare(want) >= 0, nil
+	}
+	op := "="
+	for _, candidate := range versionConstraintOps {
+		if strings.HasPrefix(constraint, candidate) {
+			op = candidate
+			constraint = strings.TrimSpace(constraint[len(candidate):])
+			break
+		}
+	}
+	want, err := parseSemver(constraint)
+	if err != nil {
+		return false, err
+	}
+	have, err := parseSemver(declared)
+	if err != nil {
+		return false, err
+	}
+	cmp := have.compare(want)
+	switch op {
+	case ">=":
+		return cmp >= 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<":
+		return cmp < 0, nil
+	default: // "=", "=="
+		return cmp == 0, nil
+	}
+}
@@ -0,0 +1,31 @@
+package gs
+
+// CallVisitor is invoked once per Call in a CallChain by CallChain.Walk, in
+// order. Returning an error stops the walk early.
+type CallVisitor func(call Call) error
+
+// Walk calls visit for every Call in cc, in order, stopping and returning
+// the first error visit produces.
+func (cc *CallChain) Walk(visit CallVisitor) error {
+	for _, call := range cc.Calls {
+		if err := visit(call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CallTransformer rewrites a single Call, returning the Call to put in its
+// place.
+type CallTransformer func(call Call) Call
+
+// Transform returns a new CallChain with every Call rewritten by transform,
+// leaving cc unmodified. It is useful for rewriting a parsed program before
+// execution, e.g. to inject or rename arguments across every call.
+func (cc *CallChain) Transform(transform CallTransformer) *CallChain {
+	calls := make([]Call, len(cc.Calls))
+	for i, call := range cc.Calls {
+		calls[i] = transform(call)
+	}
+	return &CallChain{Calls: calls}
+}
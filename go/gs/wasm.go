@@ -0,0 +1,41 @@
+package gs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonResultEnvelope is the JSON shape ExecuteJSON returns, designed for
+// callers that can't consume a Go (results, error) pair directly.
+type jsonResultEnvelope struct {
+	OK      bool     `json:"ok"`
+	Results []string `json:"results,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ExecuteJSON runs code like ExecuteWithResult, but returns a single
+// JSON-encoded envelope instead of a (results, error) pair. The core gs
+// package has no dependency that breaks GOOS=js GOARCH=wasm, so this is the
+// intended entry point for a WASM build calling in from JavaScript (see
+// examples/wasm): bind it to a global function via syscall/js and the
+// caller gets back a plain JSON string it can JSON.parse. On success,
+// "results" holds each call's result rendered via Value.String(); on
+// failure, "ok" is false and "error" holds the error's message.
+func (e *Engine) ExecuteJSON(code string) string {
+	results, err := e.ExecuteWithResult(context.Background(), code)
+	envelope := jsonResultEnvelope{OK: err == nil}
+	if err != nil {
+		envelope.Error = err.Error()
+	} else {
+		envelope.Results = make([]string, len(results))
+		for i, result := range results {
+			envelope.Results[i] = result.String()
+		}
+	}
+	data, marshalErr := json.Marshal(envelope)
+	if marshalErr != nil {
+		return fmt.Sprintf(`{"ok":false,"error":%q}`, marshalErr.Error())
+	}
+	return string(data)
+}